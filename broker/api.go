@@ -1,24 +1,67 @@
 package broker
 
 import (
-	"github.com/pivotal-cf/brokerapi/domain"
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/alphagov/paas-aiven-broker/provider"
 	"github.com/pivotal-cf/brokerapi"
+	"github.com/pivotal-cf/brokerapi/auth"
+	"github.com/pivotal-cf/brokerapi/domain/apiresponses"
 )
 
-func NewAPI(broker domain.ServiceBroker, logger lager.Logger, config Config) http.Handler {
+func NewAPI(broker *Broker, logger lager.Logger, config Config) http.Handler {
 	credentials := brokerapi.BrokerCredentials{
 		Username: config.API.BasicAuthUsername,
 		Password: config.API.BasicAuthPassword,
 	}
 
 	brokerAPI := brokerapi.New(broker, logger, credentials)
+	authWrapper := auth.NewWrapper(credentials.Username, credentials.Password)
 	mux := http.NewServeMux()
 	mux.Handle("/", brokerAPI)
 	mux.HandleFunc("/healthcheck", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	mux.Handle("GET /v2/service_instances/{instance_id}/backups", authWrapper.Wrap(listBackupsHandler(broker, logger)))
 	return mux
 }
+
+type ListBackupsResponse struct {
+	Backups []provider.Backup `json:"backups"`
+}
+
+// listBackupsHandler is an extension to the service broker API. The platform
+// sends no context with requests to extension endpoints, so like the rest of
+// the API it is only protected by the broker's credentials.
+func listBackupsHandler(broker *Broker, logger lager.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		instanceID := r.PathValue("instance_id")
+
+		backups, err := broker.ListBackups(r.Context(), instanceID)
+		if err != nil {
+			writeFailureResponse(w, logger, err)
+			return
+		}
+		writeJSON(w, logger, http.StatusOK, ListBackupsResponse{Backups: backups})
+	}
+}
+
+func writeFailureResponse(w http.ResponseWriter, logger lager.Logger, err error) {
+	failureResponse := &apiresponses.FailureResponse{}
+	if !errors.As(translateError(err), &failureResponse) {
+		writeJSON(w, logger, http.StatusInternalServerError, apiresponses.ErrorResponse{Description: internalErrorDescription})
+		return
+	}
+	writeJSON(w, logger, failureResponse.ValidatedStatusCode(logger), failureResponse.ErrorResponse())
+}
+
+func writeJSON(w http.ResponseWriter, logger lager.Logger, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("encoding-response", err)
+	}
+}
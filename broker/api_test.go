@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	. "github.com/alphagov/paas-aiven-broker/broker"
 	broker_tester "github.com/alphagov/paas-aiven-broker/broker/testing"
+	"github.com/alphagov/paas-aiven-broker/provider"
 	"github.com/alphagov/paas-aiven-broker/provider/fakes"
 	"github.com/pivotal-cf/brokerapi"
 	"github.com/pivotal-cf/brokerapi/domain"
@@ -94,6 +97,18 @@ var _ = Describe("Broker API", func() {
 			Expect(len(catalogResponse.Services[0].Plans)).To(Equal(1))
 			Expect(catalogResponse.Services[0].Plans[0].ID).To(Equal(plan1))
 		})
+
+		It("advertises that instances and bindings are retrievable", func() {
+			res := brokerTester.Services()
+			Expect(res.Code).To(Equal(http.StatusOK))
+
+			catalogResponse := apiresponses.CatalogResponse{}
+			err := json.Unmarshal(res.Body.Bytes(), &catalogResponse)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(catalogResponse.Services[0].InstancesRetrievable).To(BeTrue())
+			Expect(catalogResponse.Services[0].BindingsRetrievable).To(BeTrue())
+		})
 	})
 
 	Describe("Provision", func() {
@@ -191,6 +206,7 @@ var _ = Describe("Broker API", func() {
 					PlanID:    plan1,
 					AppGUID:   appGUID,
 				},
+				false,
 			)
 			Expect(res.Code).To(Equal(http.StatusCreated))
 
@@ -204,6 +220,29 @@ var _ = Describe("Broker API", func() {
 			Expect(binding).To(Equal(expectedBinding))
 		})
 
+		It("accepts an asynchronous binding request", func() {
+			fakeProvider.BindReturns(domain.Binding{IsAsync: true, OperationData: "binding"}, nil)
+			res := brokerTester.Bind(
+				instanceID,
+				bindingID,
+				broker_tester.RequestBody{
+					ServiceID: service1,
+					PlanID:    plan1,
+					AppGUID:   appGUID,
+				},
+				true,
+			)
+			Expect(res.Code).To(Equal(http.StatusAccepted))
+
+			asyncBindResponse := apiresponses.AsyncBindResponse{}
+			err := json.Unmarshal(res.Body.Bytes(), &asyncBindResponse)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(asyncBindResponse.OperationData).To(Equal("binding"))
+
+			_, _, asyncAllowed := fakeProvider.BindArgsForCall(0)
+			Expect(asyncAllowed).To(BeTrue())
+		})
+
 		It("responds with an internal server error if the provider errors", func() {
 			fakeProvider.BindReturns(domain.Binding{}, errors.New("some binding error"))
 			res := brokerTester.Bind(
@@ -214,11 +253,61 @@ var _ = Describe("Broker API", func() {
 					PlanID:    plan1,
 					AppGUID:   appGUID,
 				},
+				false,
 			)
 			Expect(res.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
 
+	Describe("GetBinding", func() {
+		var bindingID string
+
+		BeforeEach(func() {
+			bindingID = "bindingID"
+		})
+
+		It("returns the binding", func() {
+			fakeProvider.GetBindingReturns(domain.GetBindingSpec{Credentials: "secrets"}, nil)
+			res := brokerTester.GetBinding(instanceID, bindingID)
+			Expect(res.Code).To(Equal(http.StatusOK))
+
+			binding := apiresponses.GetBindingResponse{}
+			err := json.Unmarshal(res.Body.Bytes(), &binding)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Credentials).To(Equal("secrets"))
+		})
+
+		It("responds with not found if the binding does not exist", func() {
+			fakeProvider.GetBindingReturns(domain.GetBindingSpec{}, apiresponses.ErrBindingNotFound)
+			res := brokerTester.GetBinding(instanceID, bindingID)
+			Expect(res.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("LastBindingOperation", func() {
+		It("provides the state of the binding operation", func() {
+			fakeProvider.LastBindingOperationReturns(domain.InProgress, "description", nil)
+			res := brokerTester.LastBindingOperation(instanceID, "bindingID", "binding")
+			Expect(res.Code).To(Equal(http.StatusOK))
+
+			lastOperationResponse := apiresponses.LastOperationResponse{}
+			err := json.Unmarshal(res.Body.Bytes(), &lastOperationResponse)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedResponse := apiresponses.LastOperationResponse{
+				State:       domain.InProgress,
+				Description: "description",
+			}
+			Expect(lastOperationResponse).To(Equal(expectedResponse))
+		})
+
+		It("responds with an internal server error if the provider errors", func() {
+			fakeProvider.LastBindingOperationReturns("", "", errors.New("some last binding operation error"))
+			res := brokerTester.LastBindingOperation(instanceID, "bindingID", "binding")
+			Expect(res.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
 	Describe("Unbind", func() {
 		var bindingID string
 
@@ -310,6 +399,73 @@ var _ = Describe("Broker API", func() {
 		})
 	})
 
+	Describe("GetInstance", func() {
+		It("returns the instance details", func() {
+			fakeProvider.GetInstanceReturns(domain.GetInstanceDetailsSpec{
+				ServiceID:  service1,
+				PlanID:     plan1,
+				Parameters: map[string]interface{}{"ip_filter": "1.2.3.4"},
+			}, nil)
+			res := brokerTester.GetInstance(instanceID)
+			Expect(res.Code).To(Equal(http.StatusOK))
+
+			getInstanceResponse := apiresponses.GetInstanceResponse{}
+			err := json.Unmarshal(res.Body.Bytes(), &getInstanceResponse)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedResponse := apiresponses.GetInstanceResponse{
+				ServiceID:  service1,
+				PlanID:     plan1,
+				Parameters: map[string]interface{}{"ip_filter": "1.2.3.4"},
+			}
+			Expect(getInstanceResponse).To(Equal(expectedResponse))
+		})
+
+		It("responds with an internal server error if the provider errors", func() {
+			fakeProvider.GetInstanceReturns(domain.GetInstanceDetailsSpec{}, errors.New("some get instance error"))
+			res := brokerTester.GetInstance(instanceID)
+			Expect(res.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Describe("ListBackups", func() {
+		It("lists the backups of the instance", func() {
+			backupTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			fakeProvider.ListBackupsReturns([]provider.Backup{
+				{Name: "backup-1", Time: backupTime, Size: 1024},
+			}, nil)
+			res := brokerTester.ListBackups(instanceID)
+			Expect(res.Code).To(Equal(http.StatusOK))
+			Expect(res.Body.String()).To(MatchJSON(`{
+				"backups": [{"name": "backup-1", "time": "2024-01-02T03:04:05Z", "size": 1024}]
+			}`))
+
+			_, listBackupsData := fakeProvider.ListBackupsArgsForCall(0)
+			Expect(listBackupsData).To(Equal(provider.ListBackupsData{InstanceID: instanceID}))
+		})
+
+		It("responds with not found if the instance does not exist", func() {
+			fakeProvider.ListBackupsReturns(nil, provider.ErrInstanceNotFound)
+			res := brokerTester.ListBackups(instanceID)
+			Expect(res.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("responds with an internal server error if the provider errors", func() {
+			fakeProvider.ListBackupsReturns(nil, errors.New("some list backups error"))
+			res := brokerTester.ListBackups(instanceID)
+			Expect(res.Code).To(Equal(http.StatusInternalServerError))
+			Expect(res.Body.String()).ToNot(ContainSubstring("some list backups error"))
+		})
+
+		It("requires basic auth", func() {
+			req := httptest.NewRequest("GET", "/v2/service_instances/instanceID/backups", nil)
+			res := httptest.NewRecorder()
+			brokerAPI.ServeHTTP(res, req)
+			Expect(res.Code).To(Equal(http.StatusUnauthorized))
+			Expect(fakeProvider.ListBackupsCallCount()).To(Equal(0))
+		})
+	})
+
 	Describe("LastOperation", func() {
 		It("provides the state of the operation", func() {
 			fakeProvider.LastOperationReturns(domain.Succeeded, "description", nil)
@@ -337,11 +493,9 @@ var _ = Describe("Broker API", func() {
 			err := json.Unmarshal(res.Body.Bytes(), &lastOperationResponse)
 			Expect(err).NotTo(HaveOccurred())
 
-			expectedResponse := apiresponses.LastOperationResponse{
-				State:       "",
-				Description: lastOperationError.Error(),
-			}
-			Expect(lastOperationResponse).To(Equal(expectedResponse))
+			Expect(lastOperationResponse.State).To(BeEmpty())
+			Expect(lastOperationResponse.Description).To(ContainSubstring("An internal error occurred"))
+			Expect(lastOperationResponse.Description).ToNot(ContainSubstring(lastOperationError.Error()))
 		})
 	})
 })
@@ -2,7 +2,6 @@ package broker
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -25,20 +24,139 @@ func New(config Config, serviceProvider provider.ServiceProvider, logger lager.L
 	}
 }
 
-func (b *Broker) GetBinding(ctx context.Context, first, second string) (domain.GetBindingSpec, error) {
-	return domain.GetBindingSpec{}, fmt.Errorf("GetBinding method not implemented")
+func (b *Broker) GetBinding(ctx context.Context, instanceID, bindingID string) (domain.GetBindingSpec, error) {
+	b.logger.Debug("get-binding-start", lager.Data{
+		"instance-id": instanceID,
+		"binding-id":  bindingID,
+	})
+
+	providerCtx, cancelFunc := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelFunc()
+
+	getBindingData := provider.GetBindingData{
+		InstanceID: instanceID,
+		BindingID:  bindingID,
+	}
+
+	spec, err := b.Provider.GetBinding(providerCtx, getBindingData)
+	if err != nil {
+		return domain.GetBindingSpec{}, b.failureResponse("get-binding", err, lager.Data{
+			"instance-id": instanceID,
+			"binding-id":  bindingID,
+		})
+	}
+
+	b.logger.Debug("get-binding-success", lager.Data{
+		"instance-id": instanceID,
+		"binding-id":  bindingID,
+	})
+
+	return spec, nil
+}
+
+func (b *Broker) GetInstance(ctx context.Context, instanceID string) (domain.GetInstanceDetailsSpec, error) {
+	b.logger.Debug("get-instance-start", lager.Data{
+		"instance-id": instanceID,
+	})
+
+	providerCtx, cancelFunc := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelFunc()
+
+	getInstanceData := provider.GetInstanceData{
+		InstanceID: instanceID,
+	}
+
+	spec, err := b.Provider.GetInstance(providerCtx, getInstanceData)
+	if err != nil {
+		return domain.GetInstanceDetailsSpec{}, b.failureResponse("get-instance", err, lager.Data{
+			"instance-id": instanceID,
+		})
+	}
+
+	b.logger.Debug("get-instance-success", lager.Data{
+		"instance-id": instanceID,
+	})
+
+	return spec, nil
 }
 
-func (b *Broker) GetInstance(ctx context.Context, first string) (domain.GetInstanceDetailsSpec, error) {
-	return domain.GetInstanceDetailsSpec{}, fmt.Errorf("GetInstance method not implemented")
+func (b *Broker) ListBackups(
+	ctx context.Context,
+	instanceID string,
+) ([]provider.Backup, error) {
+	b.logger.Debug("list-backups-start", lager.Data{
+		"instance-id": instanceID,
+	})
+
+	providerCtx, cancelFunc := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelFunc()
+
+	listBackupsData := provider.ListBackupsData{
+		InstanceID: instanceID,
+	}
+
+	backups, err := b.Provider.ListBackups(providerCtx, listBackupsData)
+	if err != nil {
+		return nil, b.failureResponse("list-backups", err, lager.Data{
+			"instance-id": instanceID,
+		})
+	}
+
+	b.logger.Debug("list-backups-success", lager.Data{
+		"instance-id": instanceID,
+	})
+
+	return backups, nil
 }
 
-func (b *Broker) LastBindingOperation(ctx context.Context, first, second string, pollDetails domain.PollDetails) (domain.LastOperation, error) {
-	return domain.LastOperation{}, fmt.Errorf("LastBindingOperation method not implemented")
+func (b *Broker) LastBindingOperation(
+	ctx context.Context,
+	instanceID, bindingID string,
+	pollDetails domain.PollDetails,
+) (domain.LastOperation, error) {
+	b.logger.Debug("last-binding-operation-start", lager.Data{
+		"instance-id":    instanceID,
+		"binding-id":     bindingID,
+		"operation-data": pollDetails.OperationData,
+	})
+
+	providerCtx, cancelFunc := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelFunc()
+
+	lastBindingOperationData := provider.LastBindingOperationData{
+		InstanceID:    instanceID,
+		BindingID:     bindingID,
+		OperationData: pollDetails.OperationData,
+	}
+
+	state, description, err := b.Provider.LastBindingOperation(providerCtx, lastBindingOperationData)
+	if err != nil {
+		return domain.LastOperation{}, b.failureResponse("last-binding-operation", err, lager.Data{
+			"instance-id": instanceID,
+			"binding-id":  bindingID,
+		})
+	}
+
+	b.logger.Debug("last-binding-operation-success", lager.Data{
+		"instance-id":    instanceID,
+		"binding-id":     bindingID,
+		"operation-data": pollDetails.OperationData,
+	})
+
+	return domain.LastOperation{
+		State:       state,
+		Description: description,
+	}, nil
 }
 
 func (b *Broker) Services(ctx context.Context) ([]domain.Service, error) {
-	return b.config.Catalog.Catalog.Services, nil
+	services := make([]domain.Service, 0, len(b.config.Catalog.Catalog.Services))
+	for _, service := range b.config.Catalog.Catalog.Services {
+		service.InstancesRetrievable = true
+		service.BindingsRetrievable = true
+		services = append(services, service)
+	}
+	return services, nil
 }
 
 func (b *Broker) Provision(
@@ -79,7 +197,9 @@ func (b *Broker) Provision(
 
 	operationData, err := b.Provider.Provision(providerCtx, provisionData, true)
 	if err != nil {
-		return domain.ProvisionedServiceSpec{}, err
+		return domain.ProvisionedServiceSpec{}, b.failureResponse("provision", err, lager.Data{
+			"instance-id": instanceID,
+		})
 	}
 
 	b.logger.Debug("provision-success", lager.Data{
@@ -129,7 +249,9 @@ func (b *Broker) Deprovision(
 
 	operationData, err := b.Provider.Deprovision(providerCtx, deprovisionData)
 	if err != nil {
-		return domain.DeprovisionServiceSpec{}, err
+		return domain.DeprovisionServiceSpec{}, b.failureResponse("deprovision", err, lager.Data{
+			"instance-id": instanceID,
+		})
 	}
 
 	b.logger.Debug("deprovision-success", lager.Data{
@@ -151,9 +273,10 @@ func (b *Broker) Bind(
 	asyncAllowed bool,
 ) (domain.Binding, error) {
 	b.logger.Debug("binding-start", lager.Data{
-		"instance-id": instanceID,
-		"binding-id":  bindingID,
-		"details":     details,
+		"instance-id":   instanceID,
+		"binding-id":    bindingID,
+		"details":       details,
+		"async-allowed": asyncAllowed,
 	})
 
 	providerCtx, cancelFunc := context.WithTimeout(ctx, 30*time.Second)
@@ -165,9 +288,12 @@ func (b *Broker) Bind(
 		Details:    details,
 	}
 
-	binding, err := b.Provider.Bind(providerCtx, bindData)
+	binding, err := b.Provider.Bind(providerCtx, bindData, asyncAllowed)
 	if err != nil {
-		return domain.Binding{}, err
+		return domain.Binding{}, b.failureResponse("binding", err, lager.Data{
+			"instance-id": instanceID,
+			"binding-id":  bindingID,
+		})
 	}
 
 	b.logger.Debug("binding-success", lager.Data{
@@ -202,7 +328,10 @@ func (b *Broker) Unbind(
 
 	err := b.Provider.Unbind(providerCtx, unbindData)
 	if err != nil {
-		return domain.UnbindSpec{}, err
+		return domain.UnbindSpec{}, b.failureResponse("unbinding", err, lager.Data{
+			"instance-id": instanceID,
+			"binding-id":  bindingID,
+		})
 	}
 
 	b.logger.Debug("unbinding-success", lager.Data{
@@ -256,7 +385,9 @@ func (b *Broker) Update(
 
 	updateServiceSpec, err := b.Provider.Update(providerCtx, updateData, asyncAllowed)
 	if err != nil {
-		return domain.UpdateServiceSpec{}, err
+		return domain.UpdateServiceSpec{}, b.failureResponse("update", err, lager.Data{
+			"instance-id": instanceID,
+		})
 	}
 
 	b.logger.Debug("update-success", lager.Data{
@@ -288,7 +419,9 @@ func (b *Broker) LastOperation(
 
 	state, description, err := b.Provider.LastOperation(providerCtx, lastOperationData)
 	if err != nil {
-		return domain.LastOperation{}, err
+		return domain.LastOperation{}, b.failureResponse("last-operation", err, lager.Data{
+			"instance-id": instanceID,
+		})
 	}
 
 	b.logger.Debug("last-operation-success", lager.Data{
@@ -147,7 +147,7 @@ var _ = Describe("Broker", func() {
 
 			_, err := b.Provision(context.Background(), instanceID, validProvisionDetails, true)
 
-			Expect(err).To(MatchError("ERROR PROVISIONING"))
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
 		})
 
 		It("logs a debug message when provisioning succeeds", func() {
@@ -256,7 +256,7 @@ var _ = Describe("Broker", func() {
 
 			_, err := b.Deprovision(context.Background(), instanceID, validDeprovisionDetails, true)
 
-			Expect(err).To(MatchError("ERROR DEPROVISIONING"))
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
 		})
 
 		It("logs a debug message when deprovisioning succeeds", func() {
@@ -323,7 +323,7 @@ var _ = Describe("Broker", func() {
 			b.Bind(context.Background(), instanceID, bindingID, validBindDetails, false)
 
 			Expect(fakeProvider.BindCallCount()).To(Equal(1))
-			receivedContext, _ := fakeProvider.BindArgsForCall(0)
+			receivedContext, _, _ := fakeProvider.BindArgsForCall(0)
 
 			_, hasDeadline := receivedContext.Deadline()
 
@@ -337,7 +337,7 @@ var _ = Describe("Broker", func() {
 			b.Bind(context.Background(), instanceID, bindingID, validBindDetails, false)
 
 			Expect(fakeProvider.BindCallCount()).To(Equal(1))
-			_, bindData := fakeProvider.BindArgsForCall(0)
+			_, bindData, _ := fakeProvider.BindArgsForCall(0)
 
 			expectedBindData := provider.BindData{
 				InstanceID: instanceID,
@@ -348,6 +348,18 @@ var _ = Describe("Broker", func() {
 			Expect(bindData).To(Equal(expectedBindData))
 		})
 
+		It("passes whether async is allowed to the Provider", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+
+			b.Bind(context.Background(), instanceID, bindingID, validBindDetails, true)
+
+			Expect(fakeProvider.BindCallCount()).To(Equal(1))
+			_, _, asyncAllowed := fakeProvider.BindArgsForCall(0)
+
+			Expect(asyncAllowed).To(BeTrue())
+		})
+
 		It("errors if binding fails", func() {
 			fakeProvider := &fakes.FakeServiceProvider{}
 			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
@@ -355,7 +367,7 @@ var _ = Describe("Broker", func() {
 
 			_, err := b.Bind(context.Background(), instanceID, bindingID, validBindDetails, false)
 
-			Expect(err).To(MatchError("ERROR BINDING"))
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
 		})
 
 		It("logs a debug message when binding succeeds", func() {
@@ -447,7 +459,7 @@ var _ = Describe("Broker", func() {
 
 			_, err := b.Unbind(context.Background(), instanceID, bindingID, validUnbindDetails, false)
 
-			Expect(err).To(MatchError("ERROR UNBINDING"))
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
 		})
 
 		It("logs a debug message when unbinding succeeds", func() {
@@ -598,7 +610,7 @@ var _ = Describe("Broker", func() {
 
 			_, err := b.Update(context.Background(), instanceID, updatePlanDetails, true)
 
-			Expect(err).To(MatchError("ERROR UPDATING"))
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
 		})
 
 		It("logs a debug message when updating succeeds", func() {
@@ -625,6 +637,212 @@ var _ = Describe("Broker", func() {
 		})
 	})
 
+	Describe("GetBinding", func() {
+		var bindingID string
+
+		BeforeEach(func() {
+			bindingID = "bindingID"
+		})
+
+		It("logs a debug message when fetching the binding begins", func() {
+			logger := lager.NewLogger("broker")
+			log := gbytes.NewBuffer()
+			logger.RegisterSink(lager.NewWriterSink(log, lager.DEBUG))
+			b := New(validConfig, &fakes.FakeServiceProvider{}, logger)
+
+			b.GetBinding(context.Background(), instanceID, bindingID)
+
+			Expect(log).To(gbytes.Say("get-binding-start"))
+		})
+
+		It("sets a deadline by which the get binding request should complete", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+
+			b.GetBinding(context.Background(), instanceID, bindingID)
+
+			Expect(fakeProvider.GetBindingCallCount()).To(Equal(1))
+			receivedContext, _ := fakeProvider.GetBindingArgsForCall(0)
+
+			_, hasDeadline := receivedContext.Deadline()
+
+			Expect(hasDeadline).To(BeTrue())
+		})
+
+		It("passes the correct data to the Provider", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+
+			b.GetBinding(context.Background(), instanceID, bindingID)
+
+			Expect(fakeProvider.GetBindingCallCount()).To(Equal(1))
+			_, getBindingData := fakeProvider.GetBindingArgsForCall(0)
+
+			Expect(getBindingData).To(Equal(provider.GetBindingData{
+				InstanceID: instanceID,
+				BindingID:  bindingID,
+			}))
+		})
+
+		It("errors if getting the binding fails", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+			fakeProvider.GetBindingReturns(domain.GetBindingSpec{}, errors.New("ERROR GETTING BINDING"))
+
+			_, err := b.GetBinding(context.Background(), instanceID, bindingID)
+
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
+		})
+
+		It("returns the binding", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+			fakeProvider.GetBindingReturns(domain.GetBindingSpec{Credentials: "secrets"}, nil)
+
+			Expect(b.GetBinding(context.Background(), instanceID, bindingID)).
+				To(Equal(domain.GetBindingSpec{Credentials: "secrets"}))
+		})
+	})
+
+	Describe("LastBindingOperation", func() {
+		var (
+			bindingID     string
+			operationData string
+		)
+
+		BeforeEach(func() {
+			bindingID = "bindingID"
+			operationData = "binding"
+		})
+
+		It("logs a debug message when the last binding operation check begins", func() {
+			logger := lager.NewLogger("broker")
+			log := gbytes.NewBuffer()
+			logger.RegisterSink(lager.NewWriterSink(log, lager.DEBUG))
+			b := New(validConfig, &fakes.FakeServiceProvider{}, logger)
+
+			b.LastBindingOperation(context.Background(), instanceID, bindingID, domain.PollDetails{OperationData: operationData})
+
+			Expect(log).To(gbytes.Say("last-binding-operation-start"))
+		})
+
+		It("sets a deadline by which the last binding operation request should complete", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+
+			b.LastBindingOperation(context.Background(), instanceID, bindingID, domain.PollDetails{OperationData: operationData})
+
+			Expect(fakeProvider.LastBindingOperationCallCount()).To(Equal(1))
+			receivedContext, _ := fakeProvider.LastBindingOperationArgsForCall(0)
+
+			_, hasDeadline := receivedContext.Deadline()
+
+			Expect(hasDeadline).To(BeTrue())
+		})
+
+		It("passes the correct data to the Provider", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+
+			b.LastBindingOperation(context.Background(), instanceID, bindingID, domain.PollDetails{OperationData: operationData})
+
+			Expect(fakeProvider.LastBindingOperationCallCount()).To(Equal(1))
+			_, lastBindingOperationData := fakeProvider.LastBindingOperationArgsForCall(0)
+
+			Expect(lastBindingOperationData).To(Equal(provider.LastBindingOperationData{
+				InstanceID:    instanceID,
+				BindingID:     bindingID,
+				OperationData: operationData,
+			}))
+		})
+
+		It("errors if the last binding operation check fails", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+			fakeProvider.LastBindingOperationReturns("", "", errors.New("ERROR LAST BINDING OPERATION"))
+
+			_, err := b.LastBindingOperation(context.Background(), instanceID, bindingID, domain.PollDetails{OperationData: operationData})
+
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
+		})
+
+		It("returns the last binding operation status", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+			fakeProvider.LastBindingOperationReturns(domain.Succeeded, "Binding succeeded", nil)
+
+			Expect(b.LastBindingOperation(context.Background(), instanceID, bindingID, domain.PollDetails{OperationData: operationData})).
+				To(Equal(domain.LastOperation{
+					State:       domain.Succeeded,
+					Description: "Binding succeeded",
+				}))
+		})
+	})
+
+	Describe("GetInstance", func() {
+		It("logs a debug message when fetching the instance begins", func() {
+			logger := lager.NewLogger("broker")
+			log := gbytes.NewBuffer()
+			logger.RegisterSink(lager.NewWriterSink(log, lager.DEBUG))
+			b := New(validConfig, &fakes.FakeServiceProvider{}, logger)
+
+			b.GetInstance(context.Background(), instanceID)
+
+			Expect(log).To(gbytes.Say("get-instance-start"))
+		})
+
+		It("sets a deadline by which the get instance request should complete", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+
+			b.GetInstance(context.Background(), instanceID)
+
+			Expect(fakeProvider.GetInstanceCallCount()).To(Equal(1))
+			receivedContext, _ := fakeProvider.GetInstanceArgsForCall(0)
+
+			_, hasDeadline := receivedContext.Deadline()
+
+			Expect(hasDeadline).To(BeTrue())
+		})
+
+		It("passes the correct data to the Provider", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+
+			b.GetInstance(context.Background(), instanceID)
+
+			Expect(fakeProvider.GetInstanceCallCount()).To(Equal(1))
+			_, getInstanceData := fakeProvider.GetInstanceArgsForCall(0)
+
+			Expect(getInstanceData).To(Equal(provider.GetInstanceData{InstanceID: instanceID}))
+		})
+
+		It("errors if getting the instance fails", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+			fakeProvider.GetInstanceReturns(domain.GetInstanceDetailsSpec{}, errors.New("ERROR GETTING INSTANCE"))
+
+			_, err := b.GetInstance(context.Background(), instanceID)
+
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
+		})
+
+		It("returns the instance details", func() {
+			fakeProvider := &fakes.FakeServiceProvider{}
+			b := New(validConfig, fakeProvider, lager.NewLogger("broker"))
+			fakeProvider.GetInstanceReturns(domain.GetInstanceDetailsSpec{
+				ServiceID: service1.ID,
+				PlanID:    plan1.ID,
+			}, nil)
+
+			Expect(b.GetInstance(context.Background(), instanceID)).
+				To(Equal(domain.GetInstanceDetailsSpec{
+					ServiceID: service1.ID,
+					PlanID:    plan1.ID,
+				}))
+		})
+	})
+
 	Describe("LastOperation", func() {
 		var operationData string
 
@@ -681,7 +899,7 @@ var _ = Describe("Broker", func() {
 
 			_, err := b.LastOperation(context.Background(), instanceID, domain.PollDetails{OperationData: operationData})
 
-			Expect(err).To(MatchError("ERROR LAST OPERATION"))
+			Expect(err).To(MatchError(ContainSubstring("An internal error occurred")))
 		})
 
 		It("logs a debug message when last operation check succeeds", func() {
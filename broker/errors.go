@@ -0,0 +1,84 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/alphagov/paas-aiven-broker/provider"
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+	"github.com/pivotal-cf/brokerapi/domain/apiresponses"
+)
+
+// Error keys are returned to the platform and may be relied upon by users,
+// so they should not be changed once released
+const (
+	ErrorKeyInvalidParameters = "InvalidParameters"
+	ErrorKeyTimeout           = "Timeout"
+	ErrorKeyAivenRejected     = "AivenRequestRejected"
+	ErrorKeyInternalError     = "InternalError"
+)
+
+const internalErrorDescription = "An internal error occurred in the service broker. " +
+	"Please contact support if the problem persists."
+
+// Aiven's messages can mention details of the project, so they are only
+// logged
+const aivenRejectedDescription = "The request was rejected by Aiven. " +
+	"Please check the parameters, and contact support if the problem persists."
+
+// failureResponse logs the full detail of an error returned by the provider
+// and converts it into a failure response whose description is safe to show
+// to developers.
+func (b *Broker) failureResponse(action string, err error, data lager.Data) error {
+	logData := lager.Data{}
+	for key, value := range data {
+		logData[key] = value
+	}
+	apiErr := &aiven.APIError{}
+	if errors.As(err, &apiErr) {
+		logData["aiven-status-code"] = apiErr.StatusCode
+		logData["aiven-request-id"] = apiErr.RequestID
+		logData["aiven-message"] = apiErr.Message()
+	}
+	b.logger.Error(action+"-failed", err, logData)
+
+	return translateError(err)
+}
+
+func translateError(err error) error {
+	failureResponse := &apiresponses.FailureResponse{}
+	if errors.As(err, &failureResponse) {
+		return failureResponse
+	}
+
+	invalidParameters := provider.ErrInvalidParameters{}
+	if errors.As(err, &invalidParameters) {
+		return apiresponses.NewFailureResponseBuilder(
+			invalidParameters, http.StatusBadRequest, "invalid-parameters",
+		).WithErrorKey(ErrorKeyInvalidParameters).Build()
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apiresponses.NewFailureResponseBuilder(
+			errors.New("Timed out waiting for Aiven, please try again later"),
+			http.StatusServiceUnavailable, "timeout",
+		).WithErrorKey(ErrorKeyTimeout).Build()
+	}
+
+	if errors.As(provider.AivenFailureResponse(err), &failureResponse) {
+		return failureResponse
+	}
+
+	apiErr := &aiven.APIError{}
+	if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 {
+		return apiresponses.NewFailureResponseBuilder(
+			errors.New(aivenRejectedDescription), http.StatusUnprocessableEntity, "aiven-request-rejected",
+		).WithErrorKey(ErrorKeyAivenRejected).Build()
+	}
+
+	return apiresponses.NewFailureResponseBuilder(
+		errors.New(internalErrorDescription), http.StatusInternalServerError, "internal-error",
+	).WithErrorKey(ErrorKeyInternalError).Build()
+}
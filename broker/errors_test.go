@@ -0,0 +1,134 @@
+package broker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/alphagov/paas-aiven-broker/broker"
+	"github.com/alphagov/paas-aiven-broker/provider"
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+	"github.com/alphagov/paas-aiven-broker/provider/fakes"
+	"github.com/pivotal-cf/brokerapi/domain"
+	"github.com/pivotal-cf/brokerapi/domain/apiresponses"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Error translation", func() {
+	var (
+		fakeProvider *fakes.FakeServiceProvider
+		b            *Broker
+		log          *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		plan := domain.ServicePlan{ID: "plan1", Name: "plan1"}
+		config := Config{
+			Catalog: Catalog{apiresponses.CatalogResponse{
+				Services: []domain.Service{
+					{ID: "service1", Name: "service1", Plans: []domain.ServicePlan{plan}},
+				},
+			}},
+		}
+		logger := lager.NewLogger("broker")
+		log = gbytes.NewBuffer()
+		logger.RegisterSink(lager.NewWriterSink(log, lager.DEBUG))
+		fakeProvider = &fakes.FakeServiceProvider{}
+		b = New(config, fakeProvider, logger)
+	})
+
+	provision := func(providerErr error) *apiresponses.FailureResponse {
+		fakeProvider.ProvisionReturns(domain.ProvisionedServiceSpec{}, providerErr)
+		_, err := b.Provision(context.Background(), "instanceID", domain.ProvisionDetails{
+			ServiceID: "service1",
+			PlanID:    "plan1",
+		}, true)
+
+		failureResponse, ok := err.(*apiresponses.FailureResponse)
+		Expect(ok).To(BeTrue(), "expected a failure response but got %#v", err)
+		return failureResponse
+	}
+
+	It("passes failure responses through unchanged", func() {
+		failureResponse := provision(apiresponses.ErrInstanceAlreadyExists)
+		Expect(failureResponse).To(Equal(apiresponses.ErrInstanceAlreadyExists))
+	})
+
+	It("responds with a 400 for invalid parameters", func() {
+		failureResponse := provision(provider.ErrInvalidParameters{Message: "Invalid topic name: '#'"})
+		Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusBadRequest))
+		Expect(failureResponse.ErrorResponse()).To(Equal(apiresponses.ErrorResponse{
+			Error:       ErrorKeyInvalidParameters,
+			Description: "Invalid topic name: '#'",
+		}))
+	})
+
+	It("responds with a 503 when the request to Aiven timed out", func() {
+		failureResponse := provision(fmt.Errorf("Post \"https://api.aiven.io\": %w", context.DeadlineExceeded))
+		Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusServiceUnavailable))
+		Expect(failureResponse.ErrorResponse().(apiresponses.ErrorResponse).Error).To(Equal(ErrorKeyTimeout))
+	})
+
+	It("responds with a 503 when Aiven is unavailable", func() {
+		failureResponse := provision(&aiven.APIError{StatusCode: http.StatusBadGateway, Body: "<html>"})
+		Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusServiceUnavailable))
+		Expect(failureResponse.Error()).ToNot(ContainSubstring("<html>"))
+	})
+
+	It("responds with a 422 without Aiven's message when Aiven rejects the request", func() {
+		failureResponse := provision(&aiven.APIError{
+			Operation:  "creating service",
+			StatusCode: http.StatusBadRequest,
+			Messages:   []string{"Invalid user_config"},
+			Body:       `{"message":"Invalid user_config","internal":"secret"}`,
+		})
+		Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusUnprocessableEntity))
+		Expect(failureResponse.ErrorResponse()).To(Equal(apiresponses.ErrorResponse{
+			Error: ErrorKeyAivenRejected,
+			Description: "The request was rejected by Aiven. " +
+				"Please check the parameters, and contact support if the problem persists.",
+		}))
+		Expect(log.Contents()).To(ContainSubstring(`"aiven-message":"Invalid user_config"`))
+	})
+
+	It("responds with a fixed description when the provider translated an Aiven error", func() {
+		failureResponse := provision(provider.AivenFailureResponse(&aiven.APIError{
+			Operation:  "creating service",
+			StatusCode: http.StatusConflict,
+			Messages:   []string{"Service env-instanceid is being rebuilt"},
+			RequestID:  "req-123",
+		}))
+		Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusConflict))
+		Expect(failureResponse.Error()).ToNot(ContainSubstring("env-instanceid"))
+
+		Expect(log).To(gbytes.Say("provision-failed"))
+		Expect(log.Contents()).To(ContainSubstring(`"aiven-message":"Service env-instanceid is being rebuilt"`))
+		Expect(log.Contents()).To(ContainSubstring(`"aiven-request-id":"req-123"`))
+		Expect(log.Contents()).To(ContainSubstring(`"instance-id":"instanceID"`))
+	})
+
+	It("responds with a generic 500 for any other error", func() {
+		failureResponse := provision(errors.New("Error getting project CA: certificate was empty"))
+		Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusInternalServerError))
+		Expect(failureResponse.ErrorResponse().(apiresponses.ErrorResponse).Error).To(Equal(ErrorKeyInternalError))
+		Expect(failureResponse.Error()).ToNot(ContainSubstring("project CA"))
+	})
+
+	It("logs the full detail of the error", func() {
+		provision(&aiven.APIError{
+			Operation:  "creating service",
+			StatusCode: http.StatusBadRequest,
+			RequestID:  "req-123",
+			Body:       `{"message":"Invalid user_config"}`,
+		})
+
+		Expect(log).To(gbytes.Say("provision-failed"))
+		Expect(log.Contents()).To(ContainSubstring("Error creating service: 400 status code returned from Aiven"))
+		Expect(log.Contents()).To(ContainSubstring(`"aiven-request-id":"req-123"`))
+	})
+})
@@ -48,6 +48,10 @@ func (bt BrokerTester) Provision(instanceID string, body RequestBody, async bool
 	)
 }
 
+func (bt BrokerTester) GetInstance(instanceID string) *httptest.ResponseRecorder {
+	return bt.Get("/v2/service_instances/"+instanceID, url.Values{})
+}
+
 func (bt BrokerTester) Deprovision(instanceID, serviceID, planID string, async bool) *httptest.ResponseRecorder {
 	return bt.Delete(
 		"/v2/service_instances/"+instanceID,
@@ -60,11 +64,18 @@ func (bt BrokerTester) Deprovision(instanceID, serviceID, planID string, async b
 	)
 }
 
-func (bt BrokerTester) Bind(instanceID, bindingID string, body RequestBody) *httptest.ResponseRecorder {
+func (bt BrokerTester) Bind(instanceID, bindingID string, body RequestBody, async bool) *httptest.ResponseRecorder {
 	bodyJSON, _ := json.Marshal(body)
 	return bt.Put(
 		fmt.Sprintf("/v2/service_instances/%s/service_bindings/%s", instanceID, bindingID),
 		bytes.NewBuffer(bodyJSON),
+		url.Values{"accepts_incomplete": []string{strconv.FormatBool(async)}},
+	)
+}
+
+func (bt BrokerTester) GetBinding(instanceID, bindingID string) *httptest.ResponseRecorder {
+	return bt.Get(
+		fmt.Sprintf("/v2/service_instances/%s/service_bindings/%s", instanceID, bindingID),
 		url.Values{},
 	)
 }
@@ -111,6 +122,24 @@ func (bt BrokerTester) LastOperation(instanceID, serviceID, planID, operation st
 	)
 }
 
+func (bt BrokerTester) ListBackups(instanceID string) *httptest.ResponseRecorder {
+	return bt.Get(
+		fmt.Sprintf("/v2/service_instances/%s/backups", instanceID),
+		url.Values{},
+	)
+}
+
+func (bt BrokerTester) LastBindingOperation(instanceID, bindingID, operation string) *httptest.ResponseRecorder {
+	urlValues := url.Values{}
+	if operation != "" {
+		urlValues.Add("operation", operation)
+	}
+	return bt.Get(
+		fmt.Sprintf("/v2/service_instances/%s/service_bindings/%s/last_operation", instanceID, bindingID),
+		urlValues,
+	)
+}
+
 func (bt BrokerTester) Get(path string, params url.Values) *httptest.ResponseRecorder {
 	return bt.do(bt.newRequest("GET", path, nil, params))
 }
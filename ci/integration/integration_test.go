@@ -155,7 +155,7 @@ var _ = Describe("Broker", func() {
 				PlanID:           openSearchInitialPlanGUID,
 				OrganizationGUID: orgGUID,
 				SpaceGUID:        spaceGUID,
-			})
+			}, false)
 			Expect(res.Code).To(Equal(http.StatusCreated))
 
 			parsedResponse := BindingResponse{}
@@ -279,7 +279,7 @@ var _ = Describe("Broker", func() {
 				PlanID:           openSearchInitialPlanGUID,
 				OrganizationGUID: orgGUID,
 				SpaceGUID:        spaceGUID,
-			})
+			}, false)
 			Expect(res.Code).To(Equal(http.StatusCreated))
 
 			parsedResponse := BindingResponse{}
@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDialTimeout = 10 * time.Second
+
+	apiVersionsKey     = 18
+	apiVersionsVersion = 0
+	clientID           = "paas-aiven-broker"
+
+	// An ApiVersions response only lists the APIs of the broker, so it is
+	// far smaller than this
+	maxResponseSize = 4 << 20
+)
+
+// Client is a minimal Kafka protocol client which can send an ApiVersions
+// request to a broker. It is only used to check that newly created users can
+// connect with their client certificate.
+type Client struct {
+	tlsConfig        *tls.Config
+	BootstrapServers string
+	Timeout          time.Duration
+}
+
+// New returns a client for the comma separated bootstrap servers. Brokers are
+// reached over TLS unless tlsConfig is nil.
+func New(bootstrapServers string, tlsConfig *tls.Config) *Client {
+	return &Client{tlsConfig: tlsConfig, BootstrapServers: bootstrapServers, Timeout: defaultDialTimeout}
+}
+
+func (c *Client) Ping() error {
+	if c.BootstrapServers == "" {
+		return errors.New("no bootstrap servers")
+	}
+
+	var err error
+	for _, server := range strings.Split(c.BootstrapServers, ",") {
+		if err = c.apiVersions(strings.TrimSpace(server)); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (c *Client) dial(server string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: c.Timeout}
+	if c.tlsConfig == nil {
+		return dialer.Dial("tcp", server)
+	}
+
+	tlsConfig := c.tlsConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		host, _, err := net.SplitHostPort(server)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ServerName = host
+	}
+	return tls.DialWithDialer(dialer, "tcp", server, tlsConfig)
+}
+
+func (c *Client) apiVersions(server string) error {
+	conn, err := c.dial(server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(c.Timeout))
+
+	const correlationID = 1
+
+	// Request header v1: api key, api version, correlation id, client id.
+	// ApiVersions v0 has an empty body.
+	request := make([]byte, 0, 14+len(clientID))
+	request = binary.BigEndian.AppendUint16(request, apiVersionsKey)
+	request = binary.BigEndian.AppendUint16(request, apiVersionsVersion)
+	request = binary.BigEndian.AppendUint32(request, correlationID)
+	request = binary.BigEndian.AppendUint16(request, uint16(len(clientID)))
+	request = append(request, clientID...)
+
+	message := binary.BigEndian.AppendUint32(nil, uint32(len(request)))
+	if _, err := conn.Write(append(message, request...)); err != nil {
+		return err
+	}
+
+	var size int32
+	if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+		return err
+	}
+	if size < 6 || size > maxResponseSize {
+		return fmt.Errorf("unexpected response size: %d", size)
+	}
+	response := make([]byte, size)
+	if _, err := io.ReadFull(conn, response); err != nil {
+		return err
+	}
+
+	if id := binary.BigEndian.Uint32(response[0:4]); id != correlationID {
+		return fmt.Errorf("unexpected correlation id: %d", id)
+	}
+	if code := int16(binary.BigEndian.Uint16(response[4:6])); code != 0 {
+		return fmt.Errorf("kafka error code: %d", code)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeBroker struct {
+	listener net.Listener
+	requests chan []byte
+}
+
+// newFakeBroker answers a single request with the given correlation id and
+// error code.
+func newFakeBroker(correlationID uint32, errorCode uint16) *fakeBroker {
+	response := binary.BigEndian.AppendUint32(nil, 10)
+	response = binary.BigEndian.AppendUint32(response, correlationID)
+	response = binary.BigEndian.AppendUint16(response, errorCode)
+	response = binary.BigEndian.AppendUint32(response, 0)
+	return newFakeBrokerWithResponse(response)
+}
+
+// newFakeBrokerWithResponse answers a single request with the given bytes.
+func newFakeBrokerWithResponse(response []byte) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+
+	broker := &fakeBroker{listener: listener, requests: make(chan []byte, 1)}
+	go func() {
+		defer GinkgoRecover()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var size uint32
+		if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+			return
+		}
+		request := make([]byte, size)
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+		broker.requests <- request
+
+		conn.Write(response)
+	}()
+	return broker
+}
+
+func (b *fakeBroker) Close() {
+	b.listener.Close()
+}
+
+func (b *fakeBroker) Address() string {
+	return b.listener.Addr().String()
+}
+
+var _ = Describe("Kafka Client", func() {
+	It("should create New() client", func() {
+		client := New("kafka.aiven.io:9092", nil)
+		Expect(client.BootstrapServers).To(Equal("kafka.aiven.io:9092"))
+	})
+
+	It("should fail without bootstrap servers", func() {
+		client := New("", nil)
+		Expect(client.Ping()).To(MatchError("no bootstrap servers"))
+	})
+
+	It("should send an ApiVersions request", func() {
+		broker := newFakeBroker(1, 0)
+		defer broker.Close()
+
+		client := New(broker.Address(), nil)
+		Expect(client.Ping()).To(Succeed())
+
+		var request []byte
+		Eventually(broker.requests).Should(Receive(&request))
+		Expect(binary.BigEndian.Uint16(request[0:2])).To(Equal(uint16(apiVersionsKey)))
+		Expect(binary.BigEndian.Uint16(request[2:4])).To(Equal(uint16(apiVersionsVersion)))
+		Expect(string(request[10:])).To(Equal(clientID))
+	})
+
+	It("should try the next bootstrap server if one is unavailable", func() {
+		unavailable, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		unavailable.Close()
+
+		broker := newFakeBroker(1, 0)
+		defer broker.Close()
+
+		client := New(unavailable.Addr().String()+","+broker.Address(), nil)
+		Expect(client.Ping()).To(Succeed())
+	})
+
+	It("should fail when the broker returns an error code", func() {
+		broker := newFakeBroker(1, 35)
+		defer broker.Close()
+
+		client := New(broker.Address(), nil)
+		Expect(client.Ping()).To(MatchError("kafka error code: 35"))
+	})
+
+	It("should fail when the correlation id does not match", func() {
+		broker := newFakeBroker(7, 0)
+		defer broker.Close()
+
+		client := New(broker.Address(), nil)
+		Expect(client.Ping()).To(MatchError("unexpected correlation id: 7"))
+	})
+
+	It("should fail when the response size is too large", func() {
+		broker := newFakeBrokerWithResponse(binary.BigEndian.AppendUint32(nil, 1<<30))
+		defer broker.Close()
+
+		client := New(broker.Address(), nil)
+		Expect(client.Ping()).To(MatchError("unexpected response size: 1073741824"))
+	})
+
+	It("should fail when the response size is negative", func() {
+		broker := newFakeBrokerWithResponse(binary.BigEndian.AppendUint32(nil, 0xffffffff))
+		defer broker.Close()
+
+		client := New(broker.Address(), nil)
+		Expect(client.Ping()).To(MatchError("unexpected response size: -1"))
+	})
+})
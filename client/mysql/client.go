@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	defaultDialTimeout  = 10 * time.Second
+	defaultDatabaseName = "defaultdb"
+)
+
+// Client checks that a MySQL user can log in. It is used to check that newly
+// created users can use their credentials.
+type Client struct {
+	tlsConfig *tls.Config
+	URI       string
+	Timeout   time.Duration
+}
+
+func New(uri string, tlsConfig *tls.Config) *Client {
+	return &Client{tlsConfig: tlsConfig, URI: uri, Timeout: defaultDialTimeout}
+}
+
+// Ping opens a connection, which authenticates the user, and closes it again.
+func (c *Client) Ping() error {
+	config, err := c.driverConfig()
+	if err != nil {
+		return err
+	}
+	connector, err := mysql.NewConnector(config)
+	if err != nil {
+		return err
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
+// Aiven hands out mysql:// URIs, which the driver does not understand, so
+// they are turned into a driver config
+func (c *Client) driverConfig() (*mysql.Config, error) {
+	u, err := url.Parse(c.URI)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "mysql" {
+		return nil, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	if u.User == nil {
+		return nil, errors.New("no user found in URI")
+	}
+
+	config := mysql.NewConfig()
+	config.User = u.User.Username()
+	config.Passwd, _ = u.User.Password()
+	config.Net = "tcp"
+	config.Addr = u.Host
+	config.DBName = strings.TrimPrefix(u.Path, "/")
+	if config.DBName == "" {
+		config.DBName = defaultDatabaseName
+	}
+	config.Timeout = c.Timeout
+
+	if !strings.EqualFold(u.Query().Get("ssl-mode"), "DISABLED") {
+		tlsConfig := c.tlsConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig = tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = u.Hostname()
+		}
+		config.TLS = tlsConfig
+	}
+
+	return config, nil
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	defaultDialTimeout  = 10 * time.Second
+	defaultDatabaseName = "defaultdb"
+)
+
+// Client checks that a PostgreSQL user can log in. It is used to check that
+// newly created users can use their credentials.
+type Client struct {
+	tlsConfig *tls.Config
+	URI       string
+	Timeout   time.Duration
+}
+
+func New(uri string, tlsConfig *tls.Config) *Client {
+	return &Client{tlsConfig: tlsConfig, URI: uri, Timeout: defaultDialTimeout}
+}
+
+// Ping opens a connection, which authenticates the user, and closes it again.
+func (c *Client) Ping() error {
+	config, err := c.connConfig()
+	if err != nil {
+		return err
+	}
+
+	db := stdlib.OpenDB(*config)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
+func (c *Client) connConfig() (*pgx.ConnConfig, error) {
+	u, err := url.Parse(c.URI)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return nil, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+
+	config, err := pgx.ParseConfig(c.URI)
+	if err != nil {
+		return nil, err
+	}
+	if config.Database == "" {
+		config.Database = defaultDatabaseName
+	}
+	config.ConnectTimeout = c.Timeout
+
+	// The broker hands out URIs with sslmode=verify-full, for which pgx
+	// checks the server certificate against the system roots. Aiven signs
+	// the certificates of a project with the project's own CA, so the TLS
+	// config we were given, which trusts that CA, is used instead. The host
+	// name is still verified, whatever the sslmode, and unless TLS is
+	// disabled pgx never falls back to an unencrypted connection.
+	if config.TLSConfig != nil {
+		tlsConfig := c.tlsConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig = tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = config.Host
+		}
+		config.TLSConfig = tlsConfig
+	}
+	config.Fallbacks = nil
+
+	return config, nil
+}
@@ -0,0 +1,119 @@
+package redis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultDialTimeout = 10 * time.Second
+
+// Client is a minimal RESP client which can authenticate and ping a Redis
+// server. It is only used to check that newly created users can log in.
+type Client struct {
+	tlsConfig *tls.Config
+	URI       string
+	Timeout   time.Duration
+}
+
+func New(uri string, tlsConfig *tls.Config) *Client {
+	return &Client{tlsConfig: tlsConfig, URI: uri, Timeout: defaultDialTimeout}
+}
+
+func (c *Client) Ping() error {
+	u, err := url.Parse(c.URI)
+	if err != nil {
+		return err
+	}
+
+	conn, err := c.dial(u)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(c.Timeout))
+
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+	if u.User != nil {
+		auth := []string{"AUTH"}
+		password, hasPassword := u.User.Password()
+		if username := u.User.Username(); username != "" && hasPassword {
+			auth = append(auth, username, password)
+		} else if hasPassword {
+			auth = append(auth, password)
+		} else {
+			auth = append(auth, username)
+		}
+		if _, err := c.do(rw, auth...); err != nil {
+			return err
+		}
+	}
+
+	reply, err := c.do(rw, "PING")
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("unexpected reply to PING: %s", reply)
+	}
+	return nil
+}
+
+func (c *Client) dial(u *url.URL) (net.Conn, error) {
+	switch u.Scheme {
+	case "redis":
+		return net.DialTimeout("tcp", u.Host, c.Timeout)
+	case "rediss":
+		tlsConfig := c.tlsConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig = tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = u.Hostname()
+		}
+		dialer := &net.Dialer{Timeout: c.Timeout}
+		return tls.DialWithDialer(dialer, "tcp", u.Host, tlsConfig)
+	default:
+		return nil, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+}
+
+func (c *Client) do(rw *bufio.ReadWriter, args ...string) (string, error) {
+	fmt.Fprintf(rw, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(rw, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := rw.Flush(); err != nil {
+		return "", err
+	}
+	return readReply(rw.Reader)
+}
+
+// readReply only understands the simple string and error replies which
+// AUTH and PING return.
+func readReply(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", errors.New("empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return "", fmt.Errorf("redis error: %s", line[1:])
+	default:
+		return "", fmt.Errorf("unexpected reply: %s", line)
+	}
+}
@@ -2,11 +2,15 @@ package aiven
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -14,32 +18,58 @@ import (
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -o fakes/fake_client.go . Client
 type Client interface {
-	CreateService(params *CreateServiceInput) (string, error)
-	GetService(params *GetServiceInput) (*Service, error)
-	GetServiceTags(params *GetServiceTagsInput) (*ServiceTags, error)
-	DeleteService(params *DeleteServiceInput) error
-	CreateServiceUser(params *CreateServiceUserInput) (string, error)
-	DeleteServiceUser(params *DeleteServiceUserInput) (string, error)
-	UpdateService(params *UpdateServiceInput) (string, error)
-	UpdateServiceTags(params *UpdateServiceTagsInput) (string, error)
-	ForkService(params *ForkServiceInput) (string, error)
+	CreateService(ctx context.Context, params *CreateServiceInput) (string, error)
+	GetService(ctx context.Context, params *GetServiceInput) (*Service, error)
+	GetServiceTags(ctx context.Context, params *GetServiceTagsInput) (*ServiceTags, error)
+	DeleteService(ctx context.Context, params *DeleteServiceInput) error
+	CreateServiceUser(ctx context.Context, params *CreateServiceUserInput) (*User, error)
+	GetServiceUser(ctx context.Context, params *GetServiceUserInput) (*User, error)
+	DeleteServiceUser(ctx context.Context, params *DeleteServiceUserInput) (string, error)
+	UpdateService(ctx context.Context, params *UpdateServiceInput) (string, error)
+	UpdateServiceTags(ctx context.Context, params *UpdateServiceTagsInput) (string, error)
+	ForkService(ctx context.Context, params *ForkServiceInput) (string, error)
+	ListServiceBackups(ctx context.Context, params *ListServiceBackupsInput) ([]ServiceBackup, error)
+	GetProjectCA(ctx context.Context) (string, error)
+	CreateKafkaTopic(ctx context.Context, params *CreateKafkaTopicInput) error
+	CreateKafkaACL(ctx context.Context, params *CreateKafkaACLInput) (*KafkaACL, error)
+	ListKafkaACLs(ctx context.Context, params *ListKafkaACLsInput) ([]KafkaACL, error)
+	DeleteKafkaACL(ctx context.Context, params *DeleteKafkaACLInput) error
 }
 
 type HttpClient struct {
-	BaseURL    string
-	Token      string
-	Project    string
-	logger     lager.Logger
-	HTTPClient *http.Client
+	BaseURL     string
+	Token       string
+	Project     string
+	logger      lager.Logger
+	HTTPClient  *http.Client
+	RetryPolicy RetryPolicy
+}
+
+// RetryPolicy controls how often failed requests are sent again. Requests are
+// retried with exponential backoff and full jitter, unless Aiven asks us to
+// wait for a specific time with a Retry-After header.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+const DefaultRequestTimeout = 20 * time.Second
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
 }
 
 func NewHttpClient(baseURL, token, project string, logger lager.Logger) *HttpClient {
 	return &HttpClient{
-		BaseURL:    baseURL,
-		Token:      token,
-		Project:    project,
-		logger:     logger,
-		HTTPClient: &http.Client{},
+		BaseURL:     baseURL,
+		Token:       token,
+		Project:     project,
+		logger:      logger,
+		HTTPClient:  &http.Client{Timeout: DefaultRequestTimeout},
+		RetryPolicy: DefaultRetryPolicy,
 	}
 }
 
@@ -52,13 +82,22 @@ func (p ErrInvalidUpdate) Error() string {
 }
 
 type CreateServiceInput struct {
-	Cloud       string      `json:"cloud,omitempty"`
-	GroupName   string      `json:"group_name,omitempty"`
-	Plan        string      `json:"plan,omitempty"`
-	ServiceName string      `json:"service_name"`
-	ServiceType string      `json:"service_type"`
-	UserConfig  UserConfig  `json:"user_config"`
-	Tags        ServiceTags `json:"tags"`
+	Cloud       string       `json:"cloud,omitempty"`
+	GroupName   string       `json:"group_name,omitempty"`
+	Plan        string       `json:"plan,omitempty"`
+	ServiceName string       `json:"service_name"`
+	ServiceType string       `json:"service_type"`
+	UserConfig  UserConfig   `json:"user_config"`
+	Tags        ServiceTags  `json:"tags"`
+	Maintenance *Maintenance `json:"maintenance,omitempty"`
+
+	TerminationProtection bool `json:"termination_protection,omitempty"`
+}
+
+// Maintenance is the weekly window in which Aiven applies upgrades
+type Maintenance struct {
+	DOW  string `json:"dow"`
+	Time string `json:"time"`
 }
 
 type DeleteServiceInput struct {
@@ -76,9 +115,20 @@ type CreateServiceUserResponse struct {
 }
 
 type User struct {
-	Password string `json:"password"`
-	Type     string `json:"type"`
-	Username string `json:"username"`
+	Password   string `json:"password"`
+	Type       string `json:"type"`
+	Username   string `json:"username"`
+	AccessCert string `json:"access_cert,omitempty"`
+	AccessKey  string `json:"access_key,omitempty"`
+}
+
+type GetServiceUserInput struct {
+	ServiceName string
+	Username    string
+}
+
+type GetServiceUserResponse struct {
+	User User `json:"user"`
 }
 
 type DeleteServiceUserInput struct {
@@ -101,6 +151,11 @@ type Service struct {
 	ServiceType      string           `json:"service_type"`
 	Backups          []ServiceBackup  `json:"backups"`
 	Plan             string           `json:"plan"`
+	UserConfig       UserConfig       `json:"user_config"`
+	NodeStates       []NodeState      `json:"node_states"`
+	Maintenance      *Maintenance     `json:"maintenance"`
+
+	TerminationProtection bool `json:"termination_protection"`
 }
 
 type ServiceStatus string
@@ -113,6 +168,33 @@ const (
 	Missing     ServiceStatus = "MISSING"
 )
 
+type NodeState struct {
+	Name            string               `json:"name"`
+	Role            string               `json:"role"`
+	State           NodeStatus           `json:"state"`
+	ProgressUpdates []NodeProgressUpdate `json:"progress_updates"`
+}
+
+type NodeStatus string
+
+const (
+	NodeLeaving     NodeStatus = "leaving"
+	NodeRunning     NodeStatus = "running"
+	NodeSettingUpVM NodeStatus = "setting_up_vm"
+	NodeSyncingData NodeStatus = "syncing_data"
+	NodeTimingOut   NodeStatus = "timing_out"
+	NodeUnknown     NodeStatus = "unknown"
+)
+
+type NodeProgressUpdate struct {
+	Phase     string `json:"phase"`
+	Completed bool   `json:"completed"`
+	Current   int64  `json:"current"`
+	Min       int64  `json:"min"`
+	Max       int64  `json:"max"`
+	Unit      string `json:"unit"`
+}
+
 type ServiceUriParams struct {
 	Host     string `json:"host"`
 	Password string `json:"password"`
@@ -136,6 +218,13 @@ type ServiceTags struct {
 	RestoredFromBackup string    `json:"restored_from_backup"`
 	OriginServiceID    string    `json:"restored_from_service"`
 	RestoredFromTime   time.Time `json:"restored_from_time"`
+	// RestoredFromPointInTime is "true" when the service was restored to
+	// RestoredFromTime instead of from the latest backup before it
+	RestoredFromPointInTime string `json:"restored_from_point_in_time,omitempty"`
+	// DeleteAfter is only set when the broker has powered off a service
+	// instead of deleting it, so that it can be deleted once the grace period
+	// is over
+	DeleteAfter *time.Time `json:"delete_after,omitempty"`
 }
 
 type GetServiceTagsInput struct {
@@ -146,9 +235,13 @@ type GetServiceTagsResponse struct {
 	Tags ServiceTags `json:""`
 }
 type UpdateServiceInput struct {
-	ServiceName string     `json:"-"`
-	Plan        string     `json:"plan,omitempty"`
-	UserConfig  UserConfig `json:"user_config"`
+	ServiceName string       `json:"-"`
+	Plan        string       `json:"plan,omitempty"`
+	UserConfig  UserConfig   `json:"user_config"`
+	Maintenance *Maintenance `json:"maintenance,omitempty"`
+
+	TerminationProtection *bool `json:"termination_protection,omitempty"`
+	Powered               *bool `json:"powered,omitempty"`
 }
 type UpdateServiceTagsInput struct {
 	ServiceName string      `json:"-"`
@@ -156,13 +249,67 @@ type UpdateServiceTagsInput struct {
 }
 
 type ForkServiceInput struct {
-	Cloud       string      `json:"cloud,omitempty"`
-	GroupName   string      `json:"group_name,omitempty"`
-	Plan        string      `json:"plan,omitempty"`
-	ServiceName string      `json:"service_name"`
-	ServiceType string      `json:"service_type"`
-	UserConfig  UserConfig  `json:"user_config"`
-	Tags        ServiceTags `json:"tags"`
+	Cloud       string       `json:"cloud,omitempty"`
+	GroupName   string       `json:"group_name,omitempty"`
+	Plan        string       `json:"plan,omitempty"`
+	ServiceName string       `json:"service_name"`
+	ServiceType string       `json:"service_type"`
+	UserConfig  UserConfig   `json:"user_config"`
+	Tags        ServiceTags  `json:"tags"`
+	Maintenance *Maintenance `json:"maintenance,omitempty"`
+
+	TerminationProtection bool `json:"termination_protection,omitempty"`
+}
+
+type ListServiceBackupsInput struct {
+	ServiceName string
+}
+
+type ListServiceBackupsResponse struct {
+	Backups []ServiceBackup `json:"backups"`
+}
+
+type GetProjectCAResponse struct {
+	Certificate string `json:"certificate"`
+}
+
+type CreateKafkaTopicInput struct {
+	ServiceName string           `json:"-"`
+	TopicName   string           `json:"topic_name"`
+	Partitions  int              `json:"partitions,omitempty"`
+	Replication int              `json:"replication,omitempty"`
+	Config      KafkaTopicConfig `json:"config"`
+}
+
+type KafkaTopicConfig struct {
+	RetentionMs *int64 `json:"retention_ms,omitempty"`
+}
+
+type KafkaACL struct {
+	ID         string `json:"id,omitempty"`
+	Permission string `json:"permission"`
+	Topic      string `json:"topic"`
+	Username   string `json:"username"`
+}
+
+type CreateKafkaACLInput struct {
+	ServiceName string `json:"-"`
+	Permission  string `json:"permission"`
+	Topic       string `json:"topic"`
+	Username    string `json:"username"`
+}
+
+type ListKafkaACLsInput struct {
+	ServiceName string
+}
+
+type KafkaACLsResponse struct {
+	ACLs []KafkaACL `json:"acl"`
+}
+
+type DeleteKafkaACLInput struct {
+	ServiceName string
+	ACLID       string
 }
 
 type AivenErrorResponse struct {
@@ -170,10 +317,11 @@ type AivenErrorResponse struct {
 		Message string `json:"message"`
 		Status  int    `json:"status"`
 	} `json:"errors"`
-	Message string `json:"message"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
 }
 
-func (a *HttpClient) CreateService(params *CreateServiceInput) (string, error) {
+func (a *HttpClient) CreateService(ctx context.Context, params *CreateServiceInput) (string, error) {
 	reqBody, err := json.Marshal(params)
 	if err != nil {
 		return "", err
@@ -181,7 +329,7 @@ func (a *HttpClient) CreateService(params *CreateServiceInput) (string, error) {
 	a.logger.Info("create-service-body", lager.Data{
 		"reqBody": reqBody})
 
-	res, err := a.do("POST", fmt.Sprintf("/project/%s/service", a.Project), reqBody)
+	res, err := a.do(ctx, "POST", fmt.Sprintf("/project/%s/service", a.Project), reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -192,19 +340,19 @@ func (a *HttpClient) CreateService(params *CreateServiceInput) (string, error) {
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Error creating service: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+		return "", newAPIError("creating service", res, b)
 	}
 
 	return string(b), nil
 }
 
-func (a *HttpClient) ForkService(params *ForkServiceInput) (string, error) {
+func (a *HttpClient) ForkService(ctx context.Context, params *ForkServiceInput) (string, error) {
 	reqBody, err := json.Marshal(params)
 	if err != nil {
 		return "", err
 	}
 
-	res, err := a.do("POST", fmt.Sprintf("/project/%s/service", a.Project), reqBody)
+	res, err := a.do(ctx, "POST", fmt.Sprintf("/project/%s/service", a.Project), reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -215,7 +363,7 @@ func (a *HttpClient) ForkService(params *ForkServiceInput) (string, error) {
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Error creating service: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+		return "", newAPIError("creating service", res, b)
 	}
 
 	return string(b), nil
@@ -223,8 +371,8 @@ func (a *HttpClient) ForkService(params *ForkServiceInput) (string, error) {
 
 var ErrInstanceDoesNotExist = errors.New("Error: service instance does not exist")
 
-func (a *HttpClient) DeleteService(params *DeleteServiceInput) error {
-	res, err := a.do("DELETE", fmt.Sprintf("/project/%s/service/%s", a.Project, params.ServiceName), nil)
+func (a *HttpClient) DeleteService(ctx context.Context, params *DeleteServiceInput) error {
+	res, err := a.do(ctx, "DELETE", fmt.Sprintf("/project/%s/service/%s", a.Project, params.ServiceName), nil)
 	if err != nil {
 		return err
 	}
@@ -242,44 +390,75 @@ func (a *HttpClient) DeleteService(params *DeleteServiceInput) error {
 	if err != nil {
 		return err
 	}
-	return fmt.Errorf("Error deleting service: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+	return newAPIError("deleting service", res, b)
 }
 
-func (a *HttpClient) CreateServiceUser(params *CreateServiceUserInput) (string, error) {
+func (a *HttpClient) CreateServiceUser(ctx context.Context, params *CreateServiceUserInput) (*User, error) {
 	reqBody, err := json.Marshal(params)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	res, err := a.do("POST", fmt.Sprintf("/project/%s/service/%s/user", a.Project, params.ServiceName), reqBody)
+	res, err := a.do(ctx, "POST", fmt.Sprintf("/project/%s/service/%s/user", a.Project, params.ServiceName), reqBody)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
 		b, err := ioutil.ReadAll(res.Body)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		return "", fmt.Errorf("Error creating service user: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+		return nil, newAPIError("creating service user", res, b)
 	}
 
 	createServiceUserResponse := &CreateServiceUserResponse{}
 	if err := json.NewDecoder(res.Body).Decode(createServiceUserResponse); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if createServiceUserResponse.User.Password == "" {
-		return "", errors.New("Error creating service user: password was empty")
+		return nil, errors.New("Error creating service user: password was empty")
 	}
-	return createServiceUserResponse.User.Password, nil
+	return &createServiceUserResponse.User, nil
 }
 
 var ErrInstanceUserDoesNotExist = errors.New("Error: service instance user does not exist")
 
-func (a *HttpClient) DeleteServiceUser(params *DeleteServiceUserInput) (string, error) {
-	res, err := a.do("DELETE", fmt.Sprintf("/project/%s/service/%s/user/%s", a.Project, params.ServiceName, params.Username), nil)
+func (a *HttpClient) GetServiceUser(ctx context.Context, params *GetServiceUserInput) (*User, error) {
+	res, err := a.do(ctx, "GET", fmt.Sprintf("/project/%s/service/%s/user/%s", a.Project, params.ServiceName, params.Username), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusNotFound:
+		return nil, ErrInstanceUserDoesNotExist
+	case http.StatusOK:
+		break
+	default:
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, newAPIError("getting service user", res, b)
+	}
+
+	getServiceUserResponse := &GetServiceUserResponse{}
+	if err := json.NewDecoder(res.Body).Decode(getServiceUserResponse); err != nil {
+		return nil, err
+	}
+
+	if getServiceUserResponse.User.Password == "" {
+		return nil, errors.New("Error getting service user: password was empty")
+	}
+	return &getServiceUserResponse.User, nil
+}
+
+func (a *HttpClient) DeleteServiceUser(ctx context.Context, params *DeleteServiceUserInput) (string, error) {
+	res, err := a.do(ctx, "DELETE", fmt.Sprintf("/project/%s/service/%s/user/%s", a.Project, params.ServiceName, params.Username), nil)
 	if err != nil {
 		return "", err
 	}
@@ -291,25 +470,23 @@ func (a *HttpClient) DeleteServiceUser(params *DeleteServiceUserInput) (string,
 	}
 
 	if res.StatusCode != http.StatusOK {
-		var errorResponse AivenErrorResponse
-		jsonErr := json.Unmarshal(b, &errorResponse)
+		apiErr := newAPIError("deleting service user", res, b)
 
-		// if this response changes (again), just call it quits and sniff for 404.
-		// the only reason we're not is in case we mistake "api not here at all"
-		// for "service user doesn't exist".
-		expectedMessageIfUserWasAlreadyDeleted := "Service user does not exist"
-		if jsonErr == nil && errorResponse.Message == expectedMessageIfUserWasAlreadyDeleted {
+		// Only trust a not found error which came with an Aiven error
+		// message, so that we don't mistake "api not here at all" for
+		// "service user doesn't exist".
+		if IsNotFound(apiErr) && len(apiErr.Messages) > 0 {
 			return "", ErrInstanceUserDoesNotExist
 		}
 
-		return "", fmt.Errorf("Error deleting service user: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+		return "", apiErr
 	}
 
 	return string(b), nil
 }
 
-func (a *HttpClient) GetService(params *GetServiceInput) (*Service, error) {
-	res, err := a.do("GET", fmt.Sprintf("/project/%s/service/%s", a.Project, params.ServiceName), nil)
+func (a *HttpClient) GetService(ctx context.Context, params *GetServiceInput) (*Service, error) {
+	res, err := a.do(ctx, "GET", fmt.Sprintf("/project/%s/service/%s", a.Project, params.ServiceName), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -328,7 +505,7 @@ func (a *HttpClient) GetService(params *GetServiceInput) (*Service, error) {
 		if err != nil {
 			return nil, err
 		}
-		return nil, fmt.Errorf("Error getting service: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+		return nil, newAPIError("getting service", res, b)
 	}
 
 	getServiceResponse := &GetServiceResponse{}
@@ -354,8 +531,8 @@ func (a *HttpClient) GetService(params *GetServiceInput) (*Service, error) {
 	return &service, nil
 }
 
-func (a *HttpClient) GetServiceTags(params *GetServiceTagsInput) (*ServiceTags, error) {
-	res, err := a.do("GET", fmt.Sprintf("/project/%s/service/%s/tags", a.Project, params.ServiceName), nil)
+func (a *HttpClient) GetServiceTags(ctx context.Context, params *GetServiceTagsInput) (*ServiceTags, error) {
+	res, err := a.do(ctx, "GET", fmt.Sprintf("/project/%s/service/%s/tags", a.Project, params.ServiceName), nil)
 	if err != nil {
 		return &ServiceTags{}, err
 	}
@@ -366,7 +543,7 @@ func (a *HttpClient) GetServiceTags(params *GetServiceTagsInput) (*ServiceTags,
 		if err != nil {
 			return nil, err
 		}
-		return nil, fmt.Errorf("Error getting service tags: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+		return nil, newAPIError("getting service tags", res, b)
 	}
 
 	getServiceResponse := &GetServiceTagsResponse{}
@@ -378,13 +555,13 @@ func (a *HttpClient) GetServiceTags(params *GetServiceTagsInput) (*ServiceTags,
 
 }
 
-func (a *HttpClient) UpdateService(params *UpdateServiceInput) (string, error) {
+func (a *HttpClient) UpdateService(ctx context.Context, params *UpdateServiceInput) (string, error) {
 	reqBody, err := json.Marshal(params)
 	if err != nil {
 		return "", err
 	}
 
-	res, err := a.do("PUT", fmt.Sprintf("/project/%s/service/%s", a.Project, params.ServiceName), reqBody)
+	res, err := a.do(ctx, "PUT", fmt.Sprintf("/project/%s/service/%s", a.Project, params.ServiceName), reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -398,25 +575,26 @@ func (a *HttpClient) UpdateService(params *UpdateServiceInput) (string, error) {
 		var errorResponse AivenErrorResponse
 		jsonErr := json.Unmarshal(b, &errorResponse)
 		if jsonErr != nil {
-			return "", fmt.Errorf("Error updating service: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+			return "", newAPIError("updating service", res, b)
 		}
 		return "", ErrInvalidUpdate{fmt.Sprintf("Invalid Update: %s", errorResponse.Message)}
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Error updating service: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+		return "", newAPIError("updating service", res, b)
 	}
 
 	return string(b), nil
 }
 
-func (a *HttpClient) UpdateServiceTags(params *UpdateServiceTagsInput) (string, error) {
+func (a *HttpClient) UpdateServiceTags(ctx context.Context, params *UpdateServiceTagsInput) (string, error) {
 	reqBody, err := json.Marshal(params)
 	if err != nil {
 		return "", err
 	}
 
-	res, err := a.do("PUT", fmt.Sprintf("/project/%s/service/%s/tags", a.Project, params.ServiceName), reqBody)
+	// The tags are replaced as a whole, so the request can safely be retried
+	res, err := a.doWithRetries(ctx, "PUT", fmt.Sprintf("/project/%s/service/%s/tags", a.Project, params.ServiceName), reqBody, true)
 	if err != nil {
 		return "", err
 	}
@@ -430,33 +608,263 @@ func (a *HttpClient) UpdateServiceTags(params *UpdateServiceTagsInput) (string,
 		var errorResponse AivenErrorResponse
 		jsonErr := json.Unmarshal(b, &errorResponse)
 		if jsonErr != nil {
-			return "", fmt.Errorf("Error updating service tags: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+			return "", newAPIError("updating service tags", res, b)
 		}
 		return "", ErrInvalidUpdate{fmt.Sprintf("Invalid Update: %s", errorResponse.Message)}
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Error updating service: %d status code returned from Aiven: '%s'", res.StatusCode, b)
+		return "", newAPIError("updating service", res, b)
 	}
 
 	return string(b), nil
 }
 
-func (a *HttpClient) do(method, path string, body []byte) (*http.Response, error) {
-	req, err := a.requestBuilder(method, path, body)
+func (a *HttpClient) ListServiceBackups(ctx context.Context, params *ListServiceBackupsInput) ([]ServiceBackup, error) {
+	res, err := a.do(ctx, "GET", fmt.Sprintf("/project/%s/service/%s/backups", a.Project, params.ServiceName), nil)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrInstanceDoesNotExist
+	}
+	if res.StatusCode != http.StatusOK {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, newAPIError("listing service backups", res, b)
+	}
+
+	listServiceBackupsResponse := &ListServiceBackupsResponse{}
+	if err := json.NewDecoder(res.Body).Decode(listServiceBackupsResponse); err != nil {
+		return nil, err
+	}
+	return listServiceBackupsResponse.Backups, nil
+}
 
-	res, err := a.HTTPClient.Do(req)
+func (a *HttpClient) GetProjectCA(ctx context.Context) (string, error) {
+	res, err := a.do(ctx, "GET", fmt.Sprintf("/project/%s/kms/ca", a.Project), nil)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return "", err
+		}
+		return "", newAPIError("getting project CA", res, b)
+	}
+
+	getProjectCAResponse := &GetProjectCAResponse{}
+	if err := json.NewDecoder(res.Body).Decode(getProjectCAResponse); err != nil {
+		return "", err
+	}
+
+	if getProjectCAResponse.Certificate == "" {
+		return "", errors.New("Error getting project CA: certificate was empty")
+	}
+	return getProjectCAResponse.Certificate, nil
+}
+
+func (a *HttpClient) CreateKafkaTopic(ctx context.Context, params *CreateKafkaTopicInput) error {
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.do(ctx, "POST", fmt.Sprintf("/project/%s/service/%s/topic", a.Project, params.ServiceName), reqBody)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+		return newAPIError("creating kafka topic", res, b)
+	}
+
+	return nil
+}
+
+func (a *HttpClient) CreateKafkaACL(ctx context.Context, params *CreateKafkaACLInput) (*KafkaACL, error) {
+	reqBody, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.do(ctx, "POST", fmt.Sprintf("/project/%s/service/%s/acl", a.Project, params.ServiceName), reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, newAPIError("creating kafka ACL", res, b)
+	}
+
+	// Aiven responds with every ACL of the service, so pick out the one we
+	// have just created
+	aclsResponse := &KafkaACLsResponse{}
+	if err := json.NewDecoder(res.Body).Decode(aclsResponse); err != nil {
+		return nil, err
+	}
+
+	for _, acl := range aclsResponse.ACLs {
+		if acl.Username == params.Username && acl.Topic == params.Topic && acl.Permission == params.Permission {
+			return &acl, nil
+		}
+	}
+	return nil, errors.New("Error creating kafka ACL: ACL not found in response JSON")
+}
+
+func (a *HttpClient) ListKafkaACLs(ctx context.Context, params *ListKafkaACLsInput) ([]KafkaACL, error) {
+	res, err := a.do(ctx, "GET", fmt.Sprintf("/project/%s/service/%s/acl", a.Project, params.ServiceName), nil)
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, newAPIError("listing kafka ACLs", res, b)
+	}
+
+	aclsResponse := &KafkaACLsResponse{}
+	if err := json.NewDecoder(res.Body).Decode(aclsResponse); err != nil {
+		return nil, err
+	}
+	return aclsResponse.ACLs, nil
+}
+
+func (a *HttpClient) DeleteKafkaACL(ctx context.Context, params *DeleteKafkaACLInput) error {
+	res, err := a.do(ctx, "DELETE", fmt.Sprintf("/project/%s/service/%s/acl/%s", a.Project, params.ServiceName, params.ACLID), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return newAPIError("deleting kafka ACL", res, b)
+}
+
+// do retries GET and DELETE requests, as repeating them has no further
+// effect. Other requests are only retried when Aiven rejected them for rate
+// limiting.
+func (a *HttpClient) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	idempotent := method == http.MethodGet || method == http.MethodDelete
+	return a.doWithRetries(ctx, method, path, body, idempotent)
+}
+
+func (a *HttpClient) doWithRetries(ctx context.Context, method, path string, body []byte, idempotent bool) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		req, err := a.requestBuilder(ctx, method, path, body)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := a.HTTPClient.Do(req)
+		if attempt >= a.RetryPolicy.MaxAttempts || ctx.Err() != nil {
+			return res, err
+		}
+
+		var delay time.Duration
+		if err != nil || res.StatusCode >= http.StatusInternalServerError {
+			if !idempotent {
+				return res, err
+			}
+			delay = a.RetryPolicy.backoff(attempt)
+		} else if res.StatusCode == http.StatusTooManyRequests {
+			var ok bool
+			delay, ok = retryAfter(res)
+			if !ok {
+				delay = a.RetryPolicy.backoff(attempt)
+			}
+			// Give up rather than retrying before Aiven is ready for us
+			if delay > a.RetryPolicy.MaxDelay {
+				return res, err
+			}
+		} else {
+			return res, err
+		}
+
+		logData := lager.Data{
+			"method":  method,
+			"path":    path,
+			"attempt": attempt,
+			"delay":   delay.String(),
+		}
+		if err != nil {
+			logData["error"] = err.Error()
+		} else {
+			logData["status"] = res.StatusCode
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		a.logger.Info("retrying-aiven-request", logData)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (r RetryPolicy) backoff(attempt int) time.Duration {
+	delay := r.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(delay) + 1))
+}
+
+// retryAfter parses the Retry-After header, which holds either a number of
+// seconds or a date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
 }
 
-func (a *HttpClient) requestBuilder(method, path string, body []byte) (*http.Request, error) {
-	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1%s", a.BaseURL, path), bytes.NewBuffer(body))
+func (a *HttpClient) requestBuilder(ctx context.Context, method, path string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1%s", a.BaseURL, path), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package aiven_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -32,11 +33,147 @@ var _ = Describe("Client", func() {
 		aivenAPI.Close()
 	})
 
+	Describe("retries", func() {
+		BeforeEach(func() {
+			aivenClient.RetryPolicy = aiven.RetryPolicy{
+				MaxAttempts: 3,
+				BaseDelay:   time.Millisecond,
+				MaxDelay:    10 * time.Millisecond,
+			}
+		})
+
+		It("sets a request timeout and retry policy by default", func() {
+			client := aiven.NewHttpClient(aivenAPI.URL(), "token", "my-project", logger)
+			Expect(client.HTTPClient.Timeout).To(Equal(aiven.DefaultRequestTimeout))
+			Expect(client.RetryPolicy).To(Equal(aiven.DefaultRetryPolicy))
+		})
+
+		It("retries GET requests which fail with a server error", func() {
+			aivenAPI.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, "{}"),
+				ghttp.RespondWith(http.StatusBadGateway, "{}"),
+				ghttp.RespondWith(http.StatusOK, `{"certificate":"-----BEGIN CERTIFICATE-----"}`),
+			)
+
+			certificate, err := aivenClient.GetProjectCA(context.Background())
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificate).To(Equal("-----BEGIN CERTIFICATE-----"))
+			Expect(aivenAPI.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("gives up after the maximum number of attempts", func() {
+			aivenAPI.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, "{}"),
+				ghttp.RespondWith(http.StatusServiceUnavailable, "{}"),
+				ghttp.RespondWith(http.StatusServiceUnavailable, "{}"),
+			)
+
+			_, err := aivenClient.GetProjectCA(context.Background())
+
+			Expect(err).To(MatchError("Error getting project CA: 503 status code returned from Aiven: '{}'"))
+			Expect(aivenAPI.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("retries requests which time out", func() {
+			aivenClient.HTTPClient.Timeout = 20 * time.Millisecond
+			aivenAPI.AppendHandlers(
+				func(w http.ResponseWriter, req *http.Request) {
+					time.Sleep(100 * time.Millisecond)
+				},
+				ghttp.RespondWith(http.StatusOK, "{}"),
+			)
+
+			err := aivenClient.DeleteService(context.Background(), &aiven.DeleteServiceInput{ServiceName: "my-service"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(aivenAPI.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("stops retrying once the context is cancelled", func() {
+			// The delay is jittered, so it must be long enough that it is
+			// practically never shorter than the timeout
+			aivenClient.RetryPolicy.BaseDelay = time.Hour
+			aivenClient.RetryPolicy.MaxDelay = time.Hour
+			aivenAPI.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, "{}"),
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			_, err := aivenClient.GetProjectCA(ctx)
+
+			Expect(err).To(MatchError(ContainSubstring("context deadline exceeded")))
+			Expect(aivenAPI.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("does not send requests once the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := aivenClient.GetProjectCA(ctx)
+
+			Expect(err).To(MatchError(ContainSubstring("context canceled")))
+			Expect(aivenAPI.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("retries replacing the service tags", func() {
+			aivenAPI.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, "{}"),
+				ghttp.CombineHandlers(
+					ghttp.VerifyJSON(`{"tags":{"deploy_env":"","service_id":"","plan_id":"new-plan","organization_id":"","space_id":"","broker_name":"","restored_from_backup":"","restored_from_service":"","restored_from_time":"0001-01-01T00:00:00Z"}}`),
+					ghttp.RespondWith(http.StatusOK, "{}"),
+				),
+			)
+
+			_, err := aivenClient.UpdateServiceTags(context.Background(), &aiven.UpdateServiceTagsInput{
+				ServiceName: "my-service",
+				Tags:        aiven.ServiceTags{PlanID: "new-plan"},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(aivenAPI.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("does not retry other requests which fail with a server error", func() {
+			aivenAPI.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, "{}"),
+			)
+
+			_, err := aivenClient.CreateService(context.Background(), &aiven.CreateServiceInput{})
+
+			Expect(err).To(MatchError("Error creating service: 500 status code returned from Aiven: '{}'"))
+			Expect(aivenAPI.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("retries rate limited requests after the time given in Retry-After", func() {
+			aivenAPI.AppendHandlers(
+				ghttp.RespondWith(http.StatusTooManyRequests, "{}", http.Header{"Retry-After": []string{"0"}}),
+				ghttp.RespondWith(http.StatusOK, "{}"),
+			)
+
+			_, err := aivenClient.CreateService(context.Background(), &aiven.CreateServiceInput{})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(aivenAPI.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("gives up on rate limited requests which would need to wait too long", func() {
+			aivenAPI.AppendHandlers(
+				ghttp.RespondWith(http.StatusTooManyRequests, "{}", http.Header{"Retry-After": []string{"60"}}),
+			)
+
+			_, err := aivenClient.CreateService(context.Background(), &aiven.CreateServiceInput{})
+
+			Expect(err).To(MatchError("Error creating service: 429 status code returned from Aiven: '{}'"))
+			Expect(aivenAPI.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
 	Describe("CreateService", func() {
 		It("should make a valid request", func() {
 			userConfig := aiven.UserConfig{}
 			userConfig.OpenSearchVersion = "1"
-			userConfig.IPFilter = []string{"1.2.3.4"}
+			userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4")
 
 			createServiceInput := &aiven.CreateServiceInput{
 				Cloud:       "cloud",
@@ -55,19 +192,44 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, "{}"),
 			))
 
-			actualService, err := aivenClient.CreateService(createServiceInput)
+			actualService, err := aivenClient.CreateService(context.Background(), createServiceInput)
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(actualService).To(Equal("{}"))
 		})
 
+		It("sends the maintenance window when one is set", func() {
+			createServiceInput := &aiven.CreateServiceInput{
+				ServiceName: "name",
+				ServiceType: "type",
+				Maintenance: &aiven.Maintenance{DOW: "sunday", Time: "03:00:00"},
+			}
+			expectedBody, _ := json.Marshal(createServiceInput)
+			Expect(string(expectedBody)).To(ContainSubstring(`"maintenance":{"dow":"sunday","time":"03:00:00"}`))
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/v1/project/my-project/service"),
+				ghttp.VerifyBody(expectedBody),
+				ghttp.RespondWith(http.StatusOK, "{}"),
+			))
+
+			_, err := aivenClient.CreateService(context.Background(), createServiceInput)
+
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("omits the maintenance window when none is set", func() {
+			body, err := json.Marshal(&aiven.CreateServiceInput{ServiceName: "name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).ToNot(ContainSubstring("maintenance"))
+		})
+
 		It("returns an error if the http request fails", func() {
 			createServiceInput := &aiven.CreateServiceInput{}
 			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
 				ghttp.RespondWith(http.StatusNotFound, "{}"),
 			))
 
-			actualService, err := aivenClient.CreateService(createServiceInput)
+			actualService, err := aivenClient.CreateService(context.Background(), createServiceInput)
 
 			Expect(err).To(MatchError("Error creating service: 404 status code returned from Aiven: '{}'"))
 			Expect(actualService).To(Equal(""))
@@ -78,7 +240,7 @@ var _ = Describe("Client", func() {
 		It("should make a valid request", func() {
 			userConfig := aiven.UserConfig{}
 			userConfig.OpenSearchVersion = "1"
-			userConfig.IPFilter = []string{"1.2.3.4"}
+			userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4")
 			userConfig.ForkProject = "my-project"
 			userConfig.BackupServiceName = "some-instance"
 			userConfig.BackupName = "some-backup"
@@ -100,7 +262,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, "{}"),
 			))
 
-			actualService, err := aivenClient.CreateService(createServiceInput)
+			actualService, err := aivenClient.CreateService(context.Background(), createServiceInput)
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(actualService).To(Equal("{}"))
@@ -112,7 +274,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusNotFound, "{}"),
 			))
 
-			actualService, err := aivenClient.CreateService(createServiceInput)
+			actualService, err := aivenClient.CreateService(context.Background(), createServiceInput)
 
 			Expect(err).To(MatchError("Error creating service: 404 status code returned from Aiven: '{}'"))
 			Expect(actualService).To(Equal(""))
@@ -152,7 +314,7 @@ var _ = Describe("Client", func() {
 				)),
 			))
 
-			service, err := aivenClient.GetService(getServiceInput)
+			service, err := aivenClient.GetService(context.Background(), getServiceInput)
 			parsedTime, _ := time.Parse(time.RFC3339Nano, expectedUpdateTime)
 
 			Expect(err).ToNot(HaveOccurred())
@@ -162,6 +324,71 @@ var _ = Describe("Client", func() {
 			Expect(service.Backups).To(Equal(expectedBackups))
 		})
 
+		It("reads the IP filter from the user config", func() {
+			getServiceInput := &aiven.GetServiceInput{
+				ServiceName: "my-service",
+			}
+
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/project/my-project/service/my-service"),
+				ghttp.RespondWith(http.StatusOK, `{"service": {"service_type": "opensearch", "state": "RUNNING", "update_time": "2018-06-21T10:01:05.000040+00:00", "user_config": {"ip_filter": ["1.2.3.4", {"network": "5.6.7.8/32", "description": "office"}]}}}`),
+			))
+
+			service, err := aivenClient.GetService(context.Background(), getServiceInput)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.UserConfig.IPFilter).To(Equal(aiven.IPFilter{
+				{Network: "1.2.3.4"},
+				{Network: "5.6.7.8/32", Description: "office"},
+			}))
+		})
+
+		It("reads whether termination protection is enabled", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/project/my-project/service/my-service"),
+				ghttp.RespondWith(http.StatusOK, `{"service": {"service_type": "opensearch", "state": "RUNNING", "update_time": "2018-06-21T10:01:05.000040+00:00", "termination_protection": true}}`),
+			))
+
+			service, err := aivenClient.GetService(context.Background(), &aiven.GetServiceInput{ServiceName: "my-service"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.TerminationProtection).To(BeTrue())
+		})
+
+		It("reads the maintenance window", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/project/my-project/service/my-service"),
+				ghttp.RespondWith(http.StatusOK, `{"service": {"service_type": "opensearch", "state": "RUNNING", "update_time": "2018-06-21T10:01:05.000040+00:00", "maintenance": {"dow": "sunday", "time": "03:00:00", "updates": []}}}`),
+			))
+
+			service, err := aivenClient.GetService(context.Background(), &aiven.GetServiceInput{ServiceName: "my-service"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Maintenance).To(Equal(&aiven.Maintenance{DOW: "sunday", Time: "03:00:00"}))
+		})
+
+		It("reads the node states", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/project/my-project/service/my-service"),
+				ghttp.RespondWith(http.StatusOK, `{"service": {"service_type": "pg", "state": "REBUILDING", "update_time": "2018-06-21T10:01:05.000040+00:00", "node_states": [
+					{"name": "my-service-1", "role": "master", "state": "running", "progress_updates": []},
+					{"name": "my-service-2", "role": "standby", "state": "syncing_data", "progress_updates": [
+						{"phase": "basebackup", "completed": false, "current": 10, "min": 0, "max": 40, "unit": "bytes_compressed"}
+					]}
+				]}}`),
+			))
+
+			service, err := aivenClient.GetService(context.Background(), &aiven.GetServiceInput{ServiceName: "my-service"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.NodeStates).To(Equal([]aiven.NodeState{
+				{Name: "my-service-1", Role: "master", State: aiven.NodeRunning, ProgressUpdates: []aiven.NodeProgressUpdate{}},
+				{Name: "my-service-2", Role: "standby", State: aiven.NodeSyncingData, ProgressUpdates: []aiven.NodeProgressUpdate{
+					{Phase: "basebackup", Current: 10, Max: 40, Unit: "bytes_compressed"},
+				}},
+			}))
+		})
+
 		It("returns an error if the state is missing", func() {
 			getServiceInput := &aiven.GetServiceInput{
 				ServiceName: "my-service",
@@ -174,7 +401,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, `{"service": {"service_type": "pg", "update_time": "2018-06-21T10:01:05.000040+00:00"}}`),
 			))
 
-			_, err := aivenClient.GetService(getServiceInput)
+			_, err := aivenClient.GetService(context.Background(), getServiceInput)
 
 			Expect(err).To(MatchError("Error getting service: no state found in response JSON"))
 		})
@@ -191,7 +418,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, `{"service": {"state": "RUNNING", "update_time": "2018-06-21T10:01:05.000040+00:00"}}`),
 			))
 
-			_, err := aivenClient.GetService(getServiceInput)
+			_, err := aivenClient.GetService(context.Background(), getServiceInput)
 
 			Expect(err).To(MatchError("Error getting service: no service type found in response JSON"))
 		})
@@ -208,7 +435,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, `{"service": {"service_type": "pg", "state": "RUNNING"}}`),
 			))
 
-			_, err := aivenClient.GetService(getServiceInput)
+			_, err := aivenClient.GetService(context.Background(), getServiceInput)
 
 			Expect(err).To(MatchError("Error getting service: no update_time found in response JSON"))
 		})
@@ -225,7 +452,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusNotFound, "{}"),
 			))
 
-			_, err := aivenClient.GetService(getServiceInput)
+			_, err := aivenClient.GetService(context.Background(), getServiceInput)
 
 			Expect(err).To(MatchError(aiven.ErrInstanceDoesNotExist))
 		})
@@ -243,7 +470,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, "{}"),
 			))
 
-			err := aivenClient.DeleteService(deleteServiceInput)
+			err := aivenClient.DeleteService(context.Background(), deleteServiceInput)
 
 			Expect(err).ToNot(HaveOccurred())
 		})
@@ -254,7 +481,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusNotFound, "{}"),
 			))
 
-			err := aivenClient.DeleteService(deleteServiceInput)
+			err := aivenClient.DeleteService(context.Background(), deleteServiceInput)
 
 			Expect(err).To(MatchError(aiven.ErrInstanceDoesNotExist))
 		})
@@ -265,7 +492,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusTeapot, "{}"),
 			))
 
-			err := aivenClient.DeleteService(deleteServiceInput)
+			err := aivenClient.DeleteService(context.Background(), deleteServiceInput)
 
 			Expect(err).To(MatchError("Error deleting service: 418 status code returned from Aiven: '{}'"))
 		})
@@ -286,10 +513,14 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, `{"message":"created","user":{"password":"superdupersecret","type":"normal","username":"user"}}`),
 			))
 
-			actualPassword, err := aivenClient.CreateServiceUser(createServiceUserInput)
+			user, err := aivenClient.CreateServiceUser(context.Background(), createServiceUserInput)
 
 			Expect(err).ToNot(HaveOccurred())
-			Expect(actualPassword).To(Equal("superdupersecret"))
+			Expect(user).To(Equal(&aiven.User{
+				Password: "superdupersecret",
+				Type:     "normal",
+				Username: "user",
+			}))
 		})
 
 		It("returns an error if the http request fails", func() {
@@ -298,10 +529,10 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusForbidden, "{}"),
 			))
 
-			actualPassword, err := aivenClient.CreateServiceUser(createServiceUserInput)
+			user, err := aivenClient.CreateServiceUser(context.Background(), createServiceUserInput)
 
 			Expect(err).To(MatchError("Error creating service user: 403 status code returned from Aiven: '{}'"))
-			Expect(actualPassword).To(Equal(""))
+			Expect(user).To(BeNil())
 		})
 
 		It("returns an error if the password is empty", func() {
@@ -313,10 +544,60 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, `{"this will not":"unmarshal into the password field"}`),
 			))
 
-			actualPassword, err := aivenClient.CreateServiceUser(createServiceUserInput)
+			user, err := aivenClient.CreateServiceUser(context.Background(), createServiceUserInput)
 
 			Expect(err).To(MatchError("Error creating service user: password was empty"))
-			Expect(actualPassword).To(Equal(""))
+			Expect(user).To(BeNil())
+		})
+	})
+
+	Describe("GetServiceUser", func() {
+		It("should return the service user", func() {
+			getServiceUserInput := &aiven.GetServiceUserInput{
+				ServiceName: "my-service",
+				Username:    "my-user",
+			}
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/project/my-project/service/my-service/user/my-user"),
+				ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+				ghttp.VerifyHeaderKV("Authorization", "aivenv1 token"),
+				ghttp.RespondWith(http.StatusOK, `{"user":{"password":"superdupersecret","type":"normal","username":"my-user"}}`),
+			))
+
+			user, err := aivenClient.GetServiceUser(context.Background(), getServiceUserInput)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user).To(Equal(&aiven.User{
+				Password: "superdupersecret",
+				Type:     "normal",
+				Username: "my-user",
+			}))
+		})
+
+		It("returns ErrInstanceUserDoesNotExist if the user does not exist", func() {
+			getServiceUserInput := &aiven.GetServiceUserInput{
+				ServiceName: "my-service",
+				Username:    "my-user",
+			}
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"message":"Service user does not exist"}`),
+			))
+
+			_, err := aivenClient.GetServiceUser(context.Background(), getServiceUserInput)
+
+			Expect(err).To(Equal(aiven.ErrInstanceUserDoesNotExist))
+		})
+
+		It("returns an error if the http request fails", func() {
+			getServiceUserInput := &aiven.GetServiceUserInput{}
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusForbidden, "{}"),
+			))
+
+			user, err := aivenClient.GetServiceUser(context.Background(), getServiceUserInput)
+
+			Expect(err).To(MatchError("Error getting service user: 403 status code returned from Aiven: '{}'"))
+			Expect(user).To(BeNil())
 		})
 	})
 
@@ -333,7 +614,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, "{}"),
 			))
 
-			actualResponse, err := aivenClient.DeleteServiceUser(deleteServiceUserInput)
+			actualResponse, err := aivenClient.DeleteServiceUser(context.Background(), deleteServiceUserInput)
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(actualResponse).To(Equal("{}"))
@@ -345,7 +626,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusForbidden, "{}"),
 			))
 
-			actualResponse, err := aivenClient.DeleteServiceUser(deleteServiceUserInput)
+			actualResponse, err := aivenClient.DeleteServiceUser(context.Background(), deleteServiceUserInput)
 
 			Expect(err).To(MatchError("Error deleting service user: 403 status code returned from Aiven: '{}'"))
 			Expect(actualResponse).To(Equal(""))
@@ -357,7 +638,7 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusForbidden, `{"message": "this error was not expected"}`),
 			))
 
-			actualResponse, err := aivenClient.DeleteServiceUser(deleteServiceUserInput)
+			actualResponse, err := aivenClient.DeleteServiceUser(context.Background(), deleteServiceUserInput)
 
 			Expect(err).To(MatchError(`Error deleting service user: 403 status code returned from Aiven: '{"message": "this error was not expected"}'`))
 			Expect(actualResponse).To(Equal(""))
@@ -373,18 +654,258 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusForbidden, response),
 			))
 
-			actualResponse, err := aivenClient.DeleteServiceUser(deleteServiceUserInput)
+			actualResponse, err := aivenClient.DeleteServiceUser(context.Background(), deleteServiceUserInput)
 
 			Expect(err).To(Equal(aiven.ErrInstanceUserDoesNotExist))
 			Expect(actualResponse).To(Equal(""))
 		})
+
+		It("does not mistake a not found response without an Aiven error for a deleted user", func() {
+			deleteServiceUserInput := &aiven.DeleteServiceUserInput{}
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusNotFound, "404 page not found"),
+			))
+
+			_, err := aivenClient.DeleteServiceUser(context.Background(), deleteServiceUserInput)
+
+			Expect(err).To(MatchError("Error deleting service user: 404 status code returned from Aiven: '404 page not found'"))
+			Expect(err).ToNot(Equal(aiven.ErrInstanceUserDoesNotExist))
+		})
+	})
+
+	Describe("ListServiceBackups", func() {
+		It("should return the backups of the service", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/project/my-project/service/my-service/backups"),
+				ghttp.VerifyHeaderKV("Authorization", "aivenv1 token"),
+				ghttp.RespondWith(http.StatusOK, `{"backups":[{"backup_name":"backup-1","backup_time":"2024-01-02T03:04:05Z","data_size":1024}]}`),
+			))
+
+			backups, err := aivenClient.ListServiceBackups(context.Background(), &aiven.ListServiceBackupsInput{ServiceName: "my-service"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backups).To(Equal([]aiven.ServiceBackup{{
+				Name: "backup-1",
+				Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Size: 1024,
+			}}))
+		})
+
+		It("returns ErrInstanceDoesNotExist if the service does not exist", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"message":"Service not found"}`),
+			))
+
+			_, err := aivenClient.ListServiceBackups(context.Background(), &aiven.ListServiceBackupsInput{ServiceName: "my-service"})
+
+			Expect(err).To(Equal(aiven.ErrInstanceDoesNotExist))
+		})
+
+		It("returns an error if the http request fails", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusForbidden, "{}"),
+			))
+
+			_, err := aivenClient.ListServiceBackups(context.Background(), &aiven.ListServiceBackupsInput{ServiceName: "my-service"})
+
+			Expect(err).To(MatchError("Error listing service backups: 403 status code returned from Aiven: '{}'"))
+		})
+	})
+
+	Describe("GetProjectCA", func() {
+		It("should return the project CA certificate", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/project/my-project/kms/ca"),
+				ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+				ghttp.VerifyHeaderKV("Authorization", "aivenv1 token"),
+				ghttp.RespondWith(http.StatusOK, `{"certificate":"-----BEGIN CERTIFICATE-----"}`),
+			))
+
+			certificate, err := aivenClient.GetProjectCA(context.Background())
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificate).To(Equal("-----BEGIN CERTIFICATE-----"))
+		})
+
+		It("returns an error if the http request fails", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusForbidden, "{}"),
+			))
+
+			certificate, err := aivenClient.GetProjectCA(context.Background())
+
+			Expect(err).To(MatchError("Error getting project CA: 403 status code returned from Aiven: '{}'"))
+			Expect(certificate).To(Equal(""))
+		})
+
+		It("returns an error if the certificate is empty", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			))
+
+			_, err := aivenClient.GetProjectCA(context.Background())
+
+			Expect(err).To(MatchError("Error getting project CA: certificate was empty"))
+		})
+	})
+
+	Describe("CreateKafkaTopic", func() {
+		It("should make a valid request", func() {
+			retentionMs := int64(86400000)
+			createKafkaTopicInput := &aiven.CreateKafkaTopicInput{
+				ServiceName: "my-service",
+				TopicName:   "orders",
+				Partitions:  3,
+				Replication: 2,
+				Config:      aiven.KafkaTopicConfig{RetentionMs: &retentionMs},
+			}
+			expectedBody := []byte(`{"topic_name":"orders","partitions":3,"replication":2,"config":{"retention_ms":86400000}}`)
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/v1/project/my-project/service/my-service/topic"),
+				ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+				ghttp.VerifyHeaderKV("Authorization", "aivenv1 token"),
+				ghttp.VerifyBody(expectedBody),
+				ghttp.RespondWith(http.StatusOK, `{"message":"created"}`),
+			))
+
+			err := aivenClient.CreateKafkaTopic(context.Background(), createKafkaTopicInput)
+
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error if the http request fails", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusConflict, "{}"),
+			))
+
+			err := aivenClient.CreateKafkaTopic(context.Background(), &aiven.CreateKafkaTopicInput{})
+
+			Expect(err).To(MatchError("Error creating kafka topic: 409 status code returned from Aiven: '{}'"))
+		})
+	})
+
+	Describe("CreateKafkaACL", func() {
+		It("should make a valid request and return the new ACL", func() {
+			createKafkaACLInput := &aiven.CreateKafkaACLInput{
+				ServiceName: "my-service",
+				Permission:  "read",
+				Topic:       "orders",
+				Username:    "my-user",
+			}
+			expectedBody := []byte(`{"permission":"read","topic":"orders","username":"my-user"}`)
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/v1/project/my-project/service/my-service/acl"),
+				ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+				ghttp.VerifyHeaderKV("Authorization", "aivenv1 token"),
+				ghttp.VerifyBody(expectedBody),
+				ghttp.RespondWith(http.StatusOK, `{"acl":[
+					{"id":"default","permission":"admin","topic":"*","username":"avnadmin"},
+					{"id":"acl1","permission":"read","topic":"orders","username":"my-user"}
+				]}`),
+			))
+
+			acl, err := aivenClient.CreateKafkaACL(context.Background(), createKafkaACLInput)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acl).To(Equal(&aiven.KafkaACL{
+				ID:         "acl1",
+				Permission: "read",
+				Topic:      "orders",
+				Username:   "my-user",
+			}))
+		})
+
+		It("returns an error if the new ACL is not in the response", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"acl":[]}`),
+			))
+
+			_, err := aivenClient.CreateKafkaACL(context.Background(), &aiven.CreateKafkaACLInput{})
+
+			Expect(err).To(MatchError("Error creating kafka ACL: ACL not found in response JSON"))
+		})
+
+		It("returns an error if the http request fails", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusForbidden, "{}"),
+			))
+
+			acl, err := aivenClient.CreateKafkaACL(context.Background(), &aiven.CreateKafkaACLInput{})
+
+			Expect(err).To(MatchError("Error creating kafka ACL: 403 status code returned from Aiven: '{}'"))
+			Expect(acl).To(BeNil())
+		})
+	})
+
+	Describe("ListKafkaACLs", func() {
+		It("should return the ACLs of the service", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/project/my-project/service/my-service/acl"),
+				ghttp.VerifyHeaderKV("Authorization", "aivenv1 token"),
+				ghttp.RespondWith(http.StatusOK, `{"acl":[{"id":"acl1","permission":"read","topic":"orders","username":"my-user"}]}`),
+			))
+
+			acls, err := aivenClient.ListKafkaACLs(context.Background(), &aiven.ListKafkaACLsInput{ServiceName: "my-service"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acls).To(Equal([]aiven.KafkaACL{{
+				ID:         "acl1",
+				Permission: "read",
+				Topic:      "orders",
+				Username:   "my-user",
+			}}))
+		})
+
+		It("returns an error if the http request fails", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusForbidden, "{}"),
+			))
+
+			_, err := aivenClient.ListKafkaACLs(context.Background(), &aiven.ListKafkaACLsInput{ServiceName: "my-service"})
+
+			Expect(err).To(MatchError("Error listing kafka ACLs: 403 status code returned from Aiven: '{}'"))
+		})
+	})
+
+	Describe("DeleteKafkaACL", func() {
+		It("should make a valid request", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("DELETE", "/v1/project/my-project/service/my-service/acl/acl1"),
+				ghttp.VerifyHeaderKV("Authorization", "aivenv1 token"),
+				ghttp.RespondWith(http.StatusOK, `{"acl":[]}`),
+			))
+
+			err := aivenClient.DeleteKafkaACL(context.Background(), &aiven.DeleteKafkaACLInput{ServiceName: "my-service", ACLID: "acl1"})
+
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does not return an error if the ACL has already been deleted", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusNotFound, "{}"),
+			))
+
+			err := aivenClient.DeleteKafkaACL(context.Background(), &aiven.DeleteKafkaACLInput{ServiceName: "my-service", ACLID: "acl1"})
+
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error if the http request fails", func() {
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.RespondWith(http.StatusForbidden, "{}"),
+			))
+
+			err := aivenClient.DeleteKafkaACL(context.Background(), &aiven.DeleteKafkaACLInput{ServiceName: "my-service", ACLID: "acl1"})
+
+			Expect(err).To(MatchError("Error deleting kafka ACL: 403 status code returned from Aiven: '{}'"))
+		})
 	})
 
 	Describe("Update Service", func() {
 		It("should make a valid request", func() {
 			userConfig := aiven.UserConfig{}
 			userConfig.OpenSearchVersion = "1"
-			userConfig.IPFilter = []string{"1.2.3.4"}
+			userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4")
 
 			updateServiceInput := &aiven.UpdateServiceInput{
 				ServiceName: "my-service",
@@ -400,19 +921,54 @@ var _ = Describe("Client", func() {
 				ghttp.RespondWith(http.StatusOK, `{}`),
 			))
 
-			actualResponse, err := aivenClient.UpdateService(updateServiceInput)
+			actualResponse, err := aivenClient.UpdateService(context.Background(), updateServiceInput)
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(actualResponse).To(Equal(`{}`))
 		})
 
+		It("sends the IP filter entries which have a description as objects", func() {
+			userConfig := aiven.UserConfig{}
+			userConfig.IPFilter = aiven.IPFilter{
+				{Network: "1.2.3.4"},
+				{Network: "5.6.7.0/24", Description: "office"},
+			}
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/v1/project/my-project/service/my-service"),
+				ghttp.VerifyJSON(`{"user_config": {"ip_filter": ["1.2.3.4", {"network": "5.6.7.0/24", "description": "office"}]}}`),
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			))
+
+			_, err := aivenClient.UpdateService(context.Background(), &aiven.UpdateServiceInput{
+				ServiceName: "my-service",
+				UserConfig:  userConfig,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("can power off a service", func() {
+			powered := false
+			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/v1/project/my-project/service/my-service"),
+				ghttp.VerifyJSON(`{"user_config": {}, "powered": false}`),
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			))
+
+			_, err := aivenClient.UpdateService(context.Background(), &aiven.UpdateServiceInput{
+				ServiceName: "my-service",
+				Powered:     &powered,
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("returns an error if the http request fails", func() {
 			updateServiceInput := &aiven.UpdateServiceInput{}
 			aivenAPI.AppendHandlers(ghttp.CombineHandlers(
 				ghttp.RespondWith(http.StatusNotFound, "{}"),
 			))
 
-			actualResponse, err := aivenClient.UpdateService(updateServiceInput)
+			actualResponse, err := aivenClient.UpdateService(context.Background(), updateServiceInput)
 
 			Expect(err).To(MatchError("Error updating service: 404 status code returned from Aiven: '{}'"))
 			Expect(actualResponse).To(Equal(""))
@@ -434,7 +990,7 @@ var _ = Describe("Client", func() {
 				`),
 			))
 
-			actualResponse, err := aivenClient.UpdateService(updateServiceInput)
+			actualResponse, err := aivenClient.UpdateService(context.Background(), updateServiceInput)
 
 			Expect(err).To(MatchError(
 				aiven.ErrInvalidUpdate{"Invalid Update: Opensearch major version downgrade is not possible"},
@@ -0,0 +1,133 @@
+package aiven
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when Aiven responds with an unexpected status code.
+// Its message keeps the raw response body for the logs, whereas Messages
+// only holds the human readable messages Aiven sent back.
+type APIError struct {
+	Operation  string
+	StatusCode int
+	Messages   []string
+	RequestID  string
+	Body       string
+
+	statuses []int
+}
+
+func newAPIError(operation string, res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Operation:  operation,
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get("X-Request-Id"),
+		Body:       string(body),
+	}
+
+	var errorResponse AivenErrorResponse
+	if err := json.Unmarshal(body, &errorResponse); err != nil {
+		return apiErr
+	}
+	if errorResponse.Message != "" {
+		apiErr.Messages = append(apiErr.Messages, errorResponse.Message)
+	}
+	for _, e := range errorResponse.Errors {
+		if e.Message != "" && !contains(apiErr.Messages, e.Message) {
+			apiErr.Messages = append(apiErr.Messages, e.Message)
+		}
+		if e.Status != 0 {
+			apiErr.statuses = append(apiErr.statuses, e.Status)
+		}
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = errorResponse.RequestID
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Error %s: %d status code returned from Aiven: '%s'", e.Operation, e.StatusCode, e.Body)
+}
+
+// Message is the description Aiven gave for the error, falling back to the
+// status text when the response body could not be parsed
+func (e *APIError) Message() string {
+	if len(e.Messages) == 0 {
+		return http.StatusText(e.StatusCode)
+	}
+	return strings.Join(e.Messages, "; ")
+}
+
+// Aiven sometimes reports a different status for the individual errors than
+// for the response itself, e.g. a 403 response for a user which does not exist
+func (e *APIError) hasStatus(status int) bool {
+	if e.StatusCode == status {
+		return true
+	}
+	for _, s := range e.statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+func IsNotFound(err error) bool {
+	if errors.Is(err, ErrInstanceDoesNotExist) || errors.Is(err, ErrInstanceUserDoesNotExist) {
+		return true
+	}
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.hasStatus(http.StatusNotFound)
+}
+
+func IsConflict(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.hasStatus(http.StatusConflict)
+}
+
+// Aiven does not use a dedicated status code when a project runs out of
+// quota or credit, so the messages are checked as well
+func IsQuotaExceeded(err error) bool {
+	apiErr, ok := asAPIError(err)
+	if !ok {
+		return false
+	}
+	if apiErr.hasStatus(http.StatusPaymentRequired) {
+		return true
+	}
+	for _, message := range apiErr.Messages {
+		if strings.Contains(strings.ToLower(message), "quota") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUnavailable reports errors which are likely to go away if the request is
+// made again later, such as rate limiting or Aiven being down
+func IsUnavailable(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
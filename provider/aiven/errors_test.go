@@ -0,0 +1,99 @@
+package aiven_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("APIError", func() {
+	var (
+		aivenAPI    *ghttp.Server
+		aivenClient *aiven.HttpClient
+	)
+
+	BeforeEach(func() {
+		logger := lager.NewLogger("errors")
+		logger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+		aivenAPI = ghttp.NewServer()
+		aivenClient = aiven.NewHttpClient(aivenAPI.URL(), "token", "my-project", logger)
+		aivenClient.RetryPolicy.MaxAttempts = 1
+	})
+
+	AfterEach(func() {
+		aivenAPI.Close()
+	})
+
+	createService := func(status int, body string, header http.Header) error {
+		aivenAPI.AppendHandlers(ghttp.RespondWith(status, body, header))
+		_, err := aivenClient.CreateService(context.Background(), &aiven.CreateServiceInput{})
+		return err
+	}
+
+	It("carries the status code, messages and request ID", func() {
+		err := createService(
+			http.StatusBadRequest,
+			`{"errors":[{"message":"Invalid plan","status":400},{"message":"Invalid cloud","status":400}],"message":"Invalid plan"}`,
+			http.Header{"X-Request-Id": []string{"req-123"}},
+		)
+
+		apiErr := &aiven.APIError{}
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(apiErr.Messages).To(Equal([]string{"Invalid plan", "Invalid cloud"}))
+		Expect(apiErr.Message()).To(Equal("Invalid plan; Invalid cloud"))
+		Expect(apiErr.RequestID).To(Equal("req-123"))
+		Expect(err).To(MatchError(HavePrefix("Error creating service: 400 status code returned from Aiven: '{")))
+	})
+
+	It("falls back to the status text when the body is not JSON", func() {
+		err := createService(http.StatusBadGateway, "<html>bad gateway</html>", nil)
+
+		apiErr := &aiven.APIError{}
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Messages).To(BeEmpty())
+		Expect(apiErr.Message()).To(Equal("Bad Gateway"))
+	})
+
+	It("classifies not found errors", func() {
+		err := createService(http.StatusForbidden, `{"errors":[{"message":"Service does not exist","status":404}],"message":"Service does not exist"}`, nil)
+		Expect(aiven.IsNotFound(err)).To(BeTrue())
+		Expect(aiven.IsNotFound(aiven.ErrInstanceDoesNotExist)).To(BeTrue())
+		Expect(aiven.IsNotFound(fmt.Errorf("wrapped: %w", aiven.ErrInstanceUserDoesNotExist))).To(BeTrue())
+		Expect(aiven.IsNotFound(errors.New("Service does not exist"))).To(BeFalse())
+	})
+
+	It("classifies conflicts", func() {
+		err := createService(http.StatusConflict, `{"message":"Service name is already in use"}`, nil)
+		Expect(aiven.IsConflict(err)).To(BeTrue())
+		Expect(aiven.IsNotFound(err)).To(BeFalse())
+		Expect(aiven.IsQuotaExceeded(err)).To(BeFalse())
+	})
+
+	It("classifies exceeded quotas", func() {
+		err := createService(http.StatusForbidden, `{"message":"Project service quota exceeded"}`, nil)
+		Expect(aiven.IsQuotaExceeded(err)).To(BeTrue())
+
+		err = createService(http.StatusPaymentRequired, `{}`, nil)
+		Expect(aiven.IsQuotaExceeded(err)).To(BeTrue())
+	})
+
+	It("classifies errors which may go away when retried", func() {
+		err := createService(http.StatusServiceUnavailable, `{}`, nil)
+		Expect(aiven.IsUnavailable(err)).To(BeTrue())
+
+		err = createService(http.StatusTooManyRequests, `{}`, nil)
+		Expect(aiven.IsUnavailable(err)).To(BeTrue())
+
+		err = createService(http.StatusBadRequest, `{}`, nil)
+		Expect(aiven.IsUnavailable(err)).To(BeFalse())
+	})
+})
@@ -2,16 +2,44 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/alphagov/paas-aiven-broker/provider/aiven"
 )
 
 type FakeClient struct {
-	CreateServiceStub        func(*aiven.CreateServiceInput) (string, error)
+	CreateKafkaACLStub        func(context.Context, *aiven.CreateKafkaACLInput) (*aiven.KafkaACL, error)
+	createKafkaACLMutex       sync.RWMutex
+	createKafkaACLArgsForCall []struct {
+		arg1 context.Context
+		arg2 *aiven.CreateKafkaACLInput
+	}
+	createKafkaACLReturns struct {
+		result1 *aiven.KafkaACL
+		result2 error
+	}
+	createKafkaACLReturnsOnCall map[int]struct {
+		result1 *aiven.KafkaACL
+		result2 error
+	}
+	CreateKafkaTopicStub        func(context.Context, *aiven.CreateKafkaTopicInput) error
+	createKafkaTopicMutex       sync.RWMutex
+	createKafkaTopicArgsForCall []struct {
+		arg1 context.Context
+		arg2 *aiven.CreateKafkaTopicInput
+	}
+	createKafkaTopicReturns struct {
+		result1 error
+	}
+	createKafkaTopicReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateServiceStub        func(context.Context, *aiven.CreateServiceInput) (string, error)
 	createServiceMutex       sync.RWMutex
 	createServiceArgsForCall []struct {
-		arg1 *aiven.CreateServiceInput
+		arg1 context.Context
+		arg2 *aiven.CreateServiceInput
 	}
 	createServiceReturns struct {
 		result1 string
@@ -21,23 +49,37 @@ type FakeClient struct {
 		result1 string
 		result2 error
 	}
-	CreateServiceUserStub        func(*aiven.CreateServiceUserInput) (string, error)
+	CreateServiceUserStub        func(context.Context, *aiven.CreateServiceUserInput) (*aiven.User, error)
 	createServiceUserMutex       sync.RWMutex
 	createServiceUserArgsForCall []struct {
-		arg1 *aiven.CreateServiceUserInput
+		arg1 context.Context
+		arg2 *aiven.CreateServiceUserInput
 	}
 	createServiceUserReturns struct {
-		result1 string
+		result1 *aiven.User
 		result2 error
 	}
 	createServiceUserReturnsOnCall map[int]struct {
-		result1 string
+		result1 *aiven.User
 		result2 error
 	}
-	DeleteServiceStub        func(*aiven.DeleteServiceInput) error
+	DeleteKafkaACLStub        func(context.Context, *aiven.DeleteKafkaACLInput) error
+	deleteKafkaACLMutex       sync.RWMutex
+	deleteKafkaACLArgsForCall []struct {
+		arg1 context.Context
+		arg2 *aiven.DeleteKafkaACLInput
+	}
+	deleteKafkaACLReturns struct {
+		result1 error
+	}
+	deleteKafkaACLReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteServiceStub        func(context.Context, *aiven.DeleteServiceInput) error
 	deleteServiceMutex       sync.RWMutex
 	deleteServiceArgsForCall []struct {
-		arg1 *aiven.DeleteServiceInput
+		arg1 context.Context
+		arg2 *aiven.DeleteServiceInput
 	}
 	deleteServiceReturns struct {
 		result1 error
@@ -45,10 +87,11 @@ type FakeClient struct {
 	deleteServiceReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteServiceUserStub        func(*aiven.DeleteServiceUserInput) (string, error)
+	DeleteServiceUserStub        func(context.Context, *aiven.DeleteServiceUserInput) (string, error)
 	deleteServiceUserMutex       sync.RWMutex
 	deleteServiceUserArgsForCall []struct {
-		arg1 *aiven.DeleteServiceUserInput
+		arg1 context.Context
+		arg2 *aiven.DeleteServiceUserInput
 	}
 	deleteServiceUserReturns struct {
 		result1 string
@@ -58,10 +101,11 @@ type FakeClient struct {
 		result1 string
 		result2 error
 	}
-	ForkServiceStub        func(*aiven.ForkServiceInput) (string, error)
+	ForkServiceStub        func(context.Context, *aiven.ForkServiceInput) (string, error)
 	forkServiceMutex       sync.RWMutex
 	forkServiceArgsForCall []struct {
-		arg1 *aiven.ForkServiceInput
+		arg1 context.Context
+		arg2 *aiven.ForkServiceInput
 	}
 	forkServiceReturns struct {
 		result1 string
@@ -71,10 +115,24 @@ type FakeClient struct {
 		result1 string
 		result2 error
 	}
-	GetServiceStub        func(*aiven.GetServiceInput) (*aiven.Service, error)
+	GetProjectCAStub        func(context.Context) (string, error)
+	getProjectCAMutex       sync.RWMutex
+	getProjectCAArgsForCall []struct {
+		arg1 context.Context
+	}
+	getProjectCAReturns struct {
+		result1 string
+		result2 error
+	}
+	getProjectCAReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetServiceStub        func(context.Context, *aiven.GetServiceInput) (*aiven.Service, error)
 	getServiceMutex       sync.RWMutex
 	getServiceArgsForCall []struct {
-		arg1 *aiven.GetServiceInput
+		arg1 context.Context
+		arg2 *aiven.GetServiceInput
 	}
 	getServiceReturns struct {
 		result1 *aiven.Service
@@ -84,10 +142,11 @@ type FakeClient struct {
 		result1 *aiven.Service
 		result2 error
 	}
-	GetServiceTagsStub        func(*aiven.GetServiceTagsInput) (*aiven.ServiceTags, error)
+	GetServiceTagsStub        func(context.Context, *aiven.GetServiceTagsInput) (*aiven.ServiceTags, error)
 	getServiceTagsMutex       sync.RWMutex
 	getServiceTagsArgsForCall []struct {
-		arg1 *aiven.GetServiceTagsInput
+		arg1 context.Context
+		arg2 *aiven.GetServiceTagsInput
 	}
 	getServiceTagsReturns struct {
 		result1 *aiven.ServiceTags
@@ -97,10 +156,53 @@ type FakeClient struct {
 		result1 *aiven.ServiceTags
 		result2 error
 	}
-	UpdateServiceStub        func(*aiven.UpdateServiceInput) (string, error)
+	GetServiceUserStub        func(context.Context, *aiven.GetServiceUserInput) (*aiven.User, error)
+	getServiceUserMutex       sync.RWMutex
+	getServiceUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 *aiven.GetServiceUserInput
+	}
+	getServiceUserReturns struct {
+		result1 *aiven.User
+		result2 error
+	}
+	getServiceUserReturnsOnCall map[int]struct {
+		result1 *aiven.User
+		result2 error
+	}
+	ListKafkaACLsStub        func(context.Context, *aiven.ListKafkaACLsInput) ([]aiven.KafkaACL, error)
+	listKafkaACLsMutex       sync.RWMutex
+	listKafkaACLsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *aiven.ListKafkaACLsInput
+	}
+	listKafkaACLsReturns struct {
+		result1 []aiven.KafkaACL
+		result2 error
+	}
+	listKafkaACLsReturnsOnCall map[int]struct {
+		result1 []aiven.KafkaACL
+		result2 error
+	}
+	ListServiceBackupsStub        func(context.Context, *aiven.ListServiceBackupsInput) ([]aiven.ServiceBackup, error)
+	listServiceBackupsMutex       sync.RWMutex
+	listServiceBackupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *aiven.ListServiceBackupsInput
+	}
+	listServiceBackupsReturns struct {
+		result1 []aiven.ServiceBackup
+		result2 error
+	}
+	listServiceBackupsReturnsOnCall map[int]struct {
+		result1 []aiven.ServiceBackup
+		result2 error
+	}
+	UpdateServiceStub        func(context.Context, *aiven.UpdateServiceInput) (string, error)
 	updateServiceMutex       sync.RWMutex
 	updateServiceArgsForCall []struct {
-		arg1 *aiven.UpdateServiceInput
+		arg1 context.Context
+		arg2 *aiven.UpdateServiceInput
 	}
 	updateServiceReturns struct {
 		result1 string
@@ -110,10 +212,11 @@ type FakeClient struct {
 		result1 string
 		result2 error
 	}
-	UpdateServiceTagsStub        func(*aiven.UpdateServiceTagsInput) (string, error)
+	UpdateServiceTagsStub        func(context.Context, *aiven.UpdateServiceTagsInput) (string, error)
 	updateServiceTagsMutex       sync.RWMutex
 	updateServiceTagsArgsForCall []struct {
-		arg1 *aiven.UpdateServiceTagsInput
+		arg1 context.Context
+		arg2 *aiven.UpdateServiceTagsInput
 	}
 	updateServiceTagsReturns struct {
 		result1 string
@@ -127,18 +230,146 @@ type FakeClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeClient) CreateService(arg1 *aiven.CreateServiceInput) (string, error) {
+func (fake *FakeClient) CreateKafkaACL(arg1 context.Context, arg2 *aiven.CreateKafkaACLInput) (*aiven.KafkaACL, error) {
+	fake.createKafkaACLMutex.Lock()
+	ret, specificReturn := fake.createKafkaACLReturnsOnCall[len(fake.createKafkaACLArgsForCall)]
+	fake.createKafkaACLArgsForCall = append(fake.createKafkaACLArgsForCall, struct {
+		arg1 context.Context
+		arg2 *aiven.CreateKafkaACLInput
+	}{arg1, arg2})
+	stub := fake.CreateKafkaACLStub
+	fakeReturns := fake.createKafkaACLReturns
+	fake.recordInvocation("CreateKafkaACL", []interface{}{arg1, arg2})
+	fake.createKafkaACLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) CreateKafkaACLCallCount() int {
+	fake.createKafkaACLMutex.RLock()
+	defer fake.createKafkaACLMutex.RUnlock()
+	return len(fake.createKafkaACLArgsForCall)
+}
+
+func (fake *FakeClient) CreateKafkaACLCalls(stub func(context.Context, *aiven.CreateKafkaACLInput) (*aiven.KafkaACL, error)) {
+	fake.createKafkaACLMutex.Lock()
+	defer fake.createKafkaACLMutex.Unlock()
+	fake.CreateKafkaACLStub = stub
+}
+
+func (fake *FakeClient) CreateKafkaACLArgsForCall(i int) (context.Context, *aiven.CreateKafkaACLInput) {
+	fake.createKafkaACLMutex.RLock()
+	defer fake.createKafkaACLMutex.RUnlock()
+	argsForCall := fake.createKafkaACLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) CreateKafkaACLReturns(result1 *aiven.KafkaACL, result2 error) {
+	fake.createKafkaACLMutex.Lock()
+	defer fake.createKafkaACLMutex.Unlock()
+	fake.CreateKafkaACLStub = nil
+	fake.createKafkaACLReturns = struct {
+		result1 *aiven.KafkaACL
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CreateKafkaACLReturnsOnCall(i int, result1 *aiven.KafkaACL, result2 error) {
+	fake.createKafkaACLMutex.Lock()
+	defer fake.createKafkaACLMutex.Unlock()
+	fake.CreateKafkaACLStub = nil
+	if fake.createKafkaACLReturnsOnCall == nil {
+		fake.createKafkaACLReturnsOnCall = make(map[int]struct {
+			result1 *aiven.KafkaACL
+			result2 error
+		})
+	}
+	fake.createKafkaACLReturnsOnCall[i] = struct {
+		result1 *aiven.KafkaACL
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CreateKafkaTopic(arg1 context.Context, arg2 *aiven.CreateKafkaTopicInput) error {
+	fake.createKafkaTopicMutex.Lock()
+	ret, specificReturn := fake.createKafkaTopicReturnsOnCall[len(fake.createKafkaTopicArgsForCall)]
+	fake.createKafkaTopicArgsForCall = append(fake.createKafkaTopicArgsForCall, struct {
+		arg1 context.Context
+		arg2 *aiven.CreateKafkaTopicInput
+	}{arg1, arg2})
+	stub := fake.CreateKafkaTopicStub
+	fakeReturns := fake.createKafkaTopicReturns
+	fake.recordInvocation("CreateKafkaTopic", []interface{}{arg1, arg2})
+	fake.createKafkaTopicMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CreateKafkaTopicCallCount() int {
+	fake.createKafkaTopicMutex.RLock()
+	defer fake.createKafkaTopicMutex.RUnlock()
+	return len(fake.createKafkaTopicArgsForCall)
+}
+
+func (fake *FakeClient) CreateKafkaTopicCalls(stub func(context.Context, *aiven.CreateKafkaTopicInput) error) {
+	fake.createKafkaTopicMutex.Lock()
+	defer fake.createKafkaTopicMutex.Unlock()
+	fake.CreateKafkaTopicStub = stub
+}
+
+func (fake *FakeClient) CreateKafkaTopicArgsForCall(i int) (context.Context, *aiven.CreateKafkaTopicInput) {
+	fake.createKafkaTopicMutex.RLock()
+	defer fake.createKafkaTopicMutex.RUnlock()
+	argsForCall := fake.createKafkaTopicArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) CreateKafkaTopicReturns(result1 error) {
+	fake.createKafkaTopicMutex.Lock()
+	defer fake.createKafkaTopicMutex.Unlock()
+	fake.CreateKafkaTopicStub = nil
+	fake.createKafkaTopicReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateKafkaTopicReturnsOnCall(i int, result1 error) {
+	fake.createKafkaTopicMutex.Lock()
+	defer fake.createKafkaTopicMutex.Unlock()
+	fake.CreateKafkaTopicStub = nil
+	if fake.createKafkaTopicReturnsOnCall == nil {
+		fake.createKafkaTopicReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createKafkaTopicReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateService(arg1 context.Context, arg2 *aiven.CreateServiceInput) (string, error) {
 	fake.createServiceMutex.Lock()
 	ret, specificReturn := fake.createServiceReturnsOnCall[len(fake.createServiceArgsForCall)]
 	fake.createServiceArgsForCall = append(fake.createServiceArgsForCall, struct {
-		arg1 *aiven.CreateServiceInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.CreateServiceInput
+	}{arg1, arg2})
 	stub := fake.CreateServiceStub
 	fakeReturns := fake.createServiceReturns
-	fake.recordInvocation("CreateService", []interface{}{arg1})
+	fake.recordInvocation("CreateService", []interface{}{arg1, arg2})
 	fake.createServiceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -152,17 +383,17 @@ func (fake *FakeClient) CreateServiceCallCount() int {
 	return len(fake.createServiceArgsForCall)
 }
 
-func (fake *FakeClient) CreateServiceCalls(stub func(*aiven.CreateServiceInput) (string, error)) {
+func (fake *FakeClient) CreateServiceCalls(stub func(context.Context, *aiven.CreateServiceInput) (string, error)) {
 	fake.createServiceMutex.Lock()
 	defer fake.createServiceMutex.Unlock()
 	fake.CreateServiceStub = stub
 }
 
-func (fake *FakeClient) CreateServiceArgsForCall(i int) *aiven.CreateServiceInput {
+func (fake *FakeClient) CreateServiceArgsForCall(i int) (context.Context, *aiven.CreateServiceInput) {
 	fake.createServiceMutex.RLock()
 	defer fake.createServiceMutex.RUnlock()
 	argsForCall := fake.createServiceArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) CreateServiceReturns(result1 string, result2 error) {
@@ -191,18 +422,19 @@ func (fake *FakeClient) CreateServiceReturnsOnCall(i int, result1 string, result
 	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateServiceUser(arg1 *aiven.CreateServiceUserInput) (string, error) {
+func (fake *FakeClient) CreateServiceUser(arg1 context.Context, arg2 *aiven.CreateServiceUserInput) (*aiven.User, error) {
 	fake.createServiceUserMutex.Lock()
 	ret, specificReturn := fake.createServiceUserReturnsOnCall[len(fake.createServiceUserArgsForCall)]
 	fake.createServiceUserArgsForCall = append(fake.createServiceUserArgsForCall, struct {
-		arg1 *aiven.CreateServiceUserInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.CreateServiceUserInput
+	}{arg1, arg2})
 	stub := fake.CreateServiceUserStub
 	fakeReturns := fake.createServiceUserReturns
-	fake.recordInvocation("CreateServiceUser", []interface{}{arg1})
+	fake.recordInvocation("CreateServiceUser", []interface{}{arg1, arg2})
 	fake.createServiceUserMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -216,57 +448,120 @@ func (fake *FakeClient) CreateServiceUserCallCount() int {
 	return len(fake.createServiceUserArgsForCall)
 }
 
-func (fake *FakeClient) CreateServiceUserCalls(stub func(*aiven.CreateServiceUserInput) (string, error)) {
+func (fake *FakeClient) CreateServiceUserCalls(stub func(context.Context, *aiven.CreateServiceUserInput) (*aiven.User, error)) {
 	fake.createServiceUserMutex.Lock()
 	defer fake.createServiceUserMutex.Unlock()
 	fake.CreateServiceUserStub = stub
 }
 
-func (fake *FakeClient) CreateServiceUserArgsForCall(i int) *aiven.CreateServiceUserInput {
+func (fake *FakeClient) CreateServiceUserArgsForCall(i int) (context.Context, *aiven.CreateServiceUserInput) {
 	fake.createServiceUserMutex.RLock()
 	defer fake.createServiceUserMutex.RUnlock()
 	argsForCall := fake.createServiceUserArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeClient) CreateServiceUserReturns(result1 string, result2 error) {
+func (fake *FakeClient) CreateServiceUserReturns(result1 *aiven.User, result2 error) {
 	fake.createServiceUserMutex.Lock()
 	defer fake.createServiceUserMutex.Unlock()
 	fake.CreateServiceUserStub = nil
 	fake.createServiceUserReturns = struct {
-		result1 string
+		result1 *aiven.User
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) CreateServiceUserReturnsOnCall(i int, result1 string, result2 error) {
+func (fake *FakeClient) CreateServiceUserReturnsOnCall(i int, result1 *aiven.User, result2 error) {
 	fake.createServiceUserMutex.Lock()
 	defer fake.createServiceUserMutex.Unlock()
 	fake.CreateServiceUserStub = nil
 	if fake.createServiceUserReturnsOnCall == nil {
 		fake.createServiceUserReturnsOnCall = make(map[int]struct {
-			result1 string
+			result1 *aiven.User
 			result2 error
 		})
 	}
 	fake.createServiceUserReturnsOnCall[i] = struct {
-		result1 string
+		result1 *aiven.User
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeClient) DeleteService(arg1 *aiven.DeleteServiceInput) error {
+func (fake *FakeClient) DeleteKafkaACL(arg1 context.Context, arg2 *aiven.DeleteKafkaACLInput) error {
+	fake.deleteKafkaACLMutex.Lock()
+	ret, specificReturn := fake.deleteKafkaACLReturnsOnCall[len(fake.deleteKafkaACLArgsForCall)]
+	fake.deleteKafkaACLArgsForCall = append(fake.deleteKafkaACLArgsForCall, struct {
+		arg1 context.Context
+		arg2 *aiven.DeleteKafkaACLInput
+	}{arg1, arg2})
+	stub := fake.DeleteKafkaACLStub
+	fakeReturns := fake.deleteKafkaACLReturns
+	fake.recordInvocation("DeleteKafkaACL", []interface{}{arg1, arg2})
+	fake.deleteKafkaACLMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) DeleteKafkaACLCallCount() int {
+	fake.deleteKafkaACLMutex.RLock()
+	defer fake.deleteKafkaACLMutex.RUnlock()
+	return len(fake.deleteKafkaACLArgsForCall)
+}
+
+func (fake *FakeClient) DeleteKafkaACLCalls(stub func(context.Context, *aiven.DeleteKafkaACLInput) error) {
+	fake.deleteKafkaACLMutex.Lock()
+	defer fake.deleteKafkaACLMutex.Unlock()
+	fake.DeleteKafkaACLStub = stub
+}
+
+func (fake *FakeClient) DeleteKafkaACLArgsForCall(i int) (context.Context, *aiven.DeleteKafkaACLInput) {
+	fake.deleteKafkaACLMutex.RLock()
+	defer fake.deleteKafkaACLMutex.RUnlock()
+	argsForCall := fake.deleteKafkaACLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) DeleteKafkaACLReturns(result1 error) {
+	fake.deleteKafkaACLMutex.Lock()
+	defer fake.deleteKafkaACLMutex.Unlock()
+	fake.DeleteKafkaACLStub = nil
+	fake.deleteKafkaACLReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteKafkaACLReturnsOnCall(i int, result1 error) {
+	fake.deleteKafkaACLMutex.Lock()
+	defer fake.deleteKafkaACLMutex.Unlock()
+	fake.DeleteKafkaACLStub = nil
+	if fake.deleteKafkaACLReturnsOnCall == nil {
+		fake.deleteKafkaACLReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteKafkaACLReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DeleteService(arg1 context.Context, arg2 *aiven.DeleteServiceInput) error {
 	fake.deleteServiceMutex.Lock()
 	ret, specificReturn := fake.deleteServiceReturnsOnCall[len(fake.deleteServiceArgsForCall)]
 	fake.deleteServiceArgsForCall = append(fake.deleteServiceArgsForCall, struct {
-		arg1 *aiven.DeleteServiceInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.DeleteServiceInput
+	}{arg1, arg2})
 	stub := fake.DeleteServiceStub
 	fakeReturns := fake.deleteServiceReturns
-	fake.recordInvocation("DeleteService", []interface{}{arg1})
+	fake.recordInvocation("DeleteService", []interface{}{arg1, arg2})
 	fake.deleteServiceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -280,17 +575,17 @@ func (fake *FakeClient) DeleteServiceCallCount() int {
 	return len(fake.deleteServiceArgsForCall)
 }
 
-func (fake *FakeClient) DeleteServiceCalls(stub func(*aiven.DeleteServiceInput) error) {
+func (fake *FakeClient) DeleteServiceCalls(stub func(context.Context, *aiven.DeleteServiceInput) error) {
 	fake.deleteServiceMutex.Lock()
 	defer fake.deleteServiceMutex.Unlock()
 	fake.DeleteServiceStub = stub
 }
 
-func (fake *FakeClient) DeleteServiceArgsForCall(i int) *aiven.DeleteServiceInput {
+func (fake *FakeClient) DeleteServiceArgsForCall(i int) (context.Context, *aiven.DeleteServiceInput) {
 	fake.deleteServiceMutex.RLock()
 	defer fake.deleteServiceMutex.RUnlock()
 	argsForCall := fake.deleteServiceArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) DeleteServiceReturns(result1 error) {
@@ -316,18 +611,19 @@ func (fake *FakeClient) DeleteServiceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeClient) DeleteServiceUser(arg1 *aiven.DeleteServiceUserInput) (string, error) {
+func (fake *FakeClient) DeleteServiceUser(arg1 context.Context, arg2 *aiven.DeleteServiceUserInput) (string, error) {
 	fake.deleteServiceUserMutex.Lock()
 	ret, specificReturn := fake.deleteServiceUserReturnsOnCall[len(fake.deleteServiceUserArgsForCall)]
 	fake.deleteServiceUserArgsForCall = append(fake.deleteServiceUserArgsForCall, struct {
-		arg1 *aiven.DeleteServiceUserInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.DeleteServiceUserInput
+	}{arg1, arg2})
 	stub := fake.DeleteServiceUserStub
 	fakeReturns := fake.deleteServiceUserReturns
-	fake.recordInvocation("DeleteServiceUser", []interface{}{arg1})
+	fake.recordInvocation("DeleteServiceUser", []interface{}{arg1, arg2})
 	fake.deleteServiceUserMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -341,17 +637,17 @@ func (fake *FakeClient) DeleteServiceUserCallCount() int {
 	return len(fake.deleteServiceUserArgsForCall)
 }
 
-func (fake *FakeClient) DeleteServiceUserCalls(stub func(*aiven.DeleteServiceUserInput) (string, error)) {
+func (fake *FakeClient) DeleteServiceUserCalls(stub func(context.Context, *aiven.DeleteServiceUserInput) (string, error)) {
 	fake.deleteServiceUserMutex.Lock()
 	defer fake.deleteServiceUserMutex.Unlock()
 	fake.DeleteServiceUserStub = stub
 }
 
-func (fake *FakeClient) DeleteServiceUserArgsForCall(i int) *aiven.DeleteServiceUserInput {
+func (fake *FakeClient) DeleteServiceUserArgsForCall(i int) (context.Context, *aiven.DeleteServiceUserInput) {
 	fake.deleteServiceUserMutex.RLock()
 	defer fake.deleteServiceUserMutex.RUnlock()
 	argsForCall := fake.deleteServiceUserArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) DeleteServiceUserReturns(result1 string, result2 error) {
@@ -380,18 +676,19 @@ func (fake *FakeClient) DeleteServiceUserReturnsOnCall(i int, result1 string, re
 	}{result1, result2}
 }
 
-func (fake *FakeClient) ForkService(arg1 *aiven.ForkServiceInput) (string, error) {
+func (fake *FakeClient) ForkService(arg1 context.Context, arg2 *aiven.ForkServiceInput) (string, error) {
 	fake.forkServiceMutex.Lock()
 	ret, specificReturn := fake.forkServiceReturnsOnCall[len(fake.forkServiceArgsForCall)]
 	fake.forkServiceArgsForCall = append(fake.forkServiceArgsForCall, struct {
-		arg1 *aiven.ForkServiceInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.ForkServiceInput
+	}{arg1, arg2})
 	stub := fake.ForkServiceStub
 	fakeReturns := fake.forkServiceReturns
-	fake.recordInvocation("ForkService", []interface{}{arg1})
+	fake.recordInvocation("ForkService", []interface{}{arg1, arg2})
 	fake.forkServiceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -405,17 +702,17 @@ func (fake *FakeClient) ForkServiceCallCount() int {
 	return len(fake.forkServiceArgsForCall)
 }
 
-func (fake *FakeClient) ForkServiceCalls(stub func(*aiven.ForkServiceInput) (string, error)) {
+func (fake *FakeClient) ForkServiceCalls(stub func(context.Context, *aiven.ForkServiceInput) (string, error)) {
 	fake.forkServiceMutex.Lock()
 	defer fake.forkServiceMutex.Unlock()
 	fake.ForkServiceStub = stub
 }
 
-func (fake *FakeClient) ForkServiceArgsForCall(i int) *aiven.ForkServiceInput {
+func (fake *FakeClient) ForkServiceArgsForCall(i int) (context.Context, *aiven.ForkServiceInput) {
 	fake.forkServiceMutex.RLock()
 	defer fake.forkServiceMutex.RUnlock()
 	argsForCall := fake.forkServiceArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) ForkServiceReturns(result1 string, result2 error) {
@@ -444,18 +741,83 @@ func (fake *FakeClient) ForkServiceReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
-func (fake *FakeClient) GetService(arg1 *aiven.GetServiceInput) (*aiven.Service, error) {
+func (fake *FakeClient) GetProjectCA(arg1 context.Context) (string, error) {
+	fake.getProjectCAMutex.Lock()
+	ret, specificReturn := fake.getProjectCAReturnsOnCall[len(fake.getProjectCAArgsForCall)]
+	fake.getProjectCAArgsForCall = append(fake.getProjectCAArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetProjectCAStub
+	fakeReturns := fake.getProjectCAReturns
+	fake.recordInvocation("GetProjectCA", []interface{}{arg1})
+	fake.getProjectCAMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetProjectCACallCount() int {
+	fake.getProjectCAMutex.RLock()
+	defer fake.getProjectCAMutex.RUnlock()
+	return len(fake.getProjectCAArgsForCall)
+}
+
+func (fake *FakeClient) GetProjectCACalls(stub func(context.Context) (string, error)) {
+	fake.getProjectCAMutex.Lock()
+	defer fake.getProjectCAMutex.Unlock()
+	fake.GetProjectCAStub = stub
+}
+
+func (fake *FakeClient) GetProjectCAArgsForCall(i int) context.Context {
+	fake.getProjectCAMutex.RLock()
+	defer fake.getProjectCAMutex.RUnlock()
+	argsForCall := fake.getProjectCAArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) GetProjectCAReturns(result1 string, result2 error) {
+	fake.getProjectCAMutex.Lock()
+	defer fake.getProjectCAMutex.Unlock()
+	fake.GetProjectCAStub = nil
+	fake.getProjectCAReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetProjectCAReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getProjectCAMutex.Lock()
+	defer fake.getProjectCAMutex.Unlock()
+	fake.GetProjectCAStub = nil
+	if fake.getProjectCAReturnsOnCall == nil {
+		fake.getProjectCAReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getProjectCAReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetService(arg1 context.Context, arg2 *aiven.GetServiceInput) (*aiven.Service, error) {
 	fake.getServiceMutex.Lock()
 	ret, specificReturn := fake.getServiceReturnsOnCall[len(fake.getServiceArgsForCall)]
 	fake.getServiceArgsForCall = append(fake.getServiceArgsForCall, struct {
-		arg1 *aiven.GetServiceInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.GetServiceInput
+	}{arg1, arg2})
 	stub := fake.GetServiceStub
 	fakeReturns := fake.getServiceReturns
-	fake.recordInvocation("GetService", []interface{}{arg1})
+	fake.recordInvocation("GetService", []interface{}{arg1, arg2})
 	fake.getServiceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -469,17 +831,17 @@ func (fake *FakeClient) GetServiceCallCount() int {
 	return len(fake.getServiceArgsForCall)
 }
 
-func (fake *FakeClient) GetServiceCalls(stub func(*aiven.GetServiceInput) (*aiven.Service, error)) {
+func (fake *FakeClient) GetServiceCalls(stub func(context.Context, *aiven.GetServiceInput) (*aiven.Service, error)) {
 	fake.getServiceMutex.Lock()
 	defer fake.getServiceMutex.Unlock()
 	fake.GetServiceStub = stub
 }
 
-func (fake *FakeClient) GetServiceArgsForCall(i int) *aiven.GetServiceInput {
+func (fake *FakeClient) GetServiceArgsForCall(i int) (context.Context, *aiven.GetServiceInput) {
 	fake.getServiceMutex.RLock()
 	defer fake.getServiceMutex.RUnlock()
 	argsForCall := fake.getServiceArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) GetServiceReturns(result1 *aiven.Service, result2 error) {
@@ -508,18 +870,19 @@ func (fake *FakeClient) GetServiceReturnsOnCall(i int, result1 *aiven.Service, r
 	}{result1, result2}
 }
 
-func (fake *FakeClient) GetServiceTags(arg1 *aiven.GetServiceTagsInput) (*aiven.ServiceTags, error) {
+func (fake *FakeClient) GetServiceTags(arg1 context.Context, arg2 *aiven.GetServiceTagsInput) (*aiven.ServiceTags, error) {
 	fake.getServiceTagsMutex.Lock()
 	ret, specificReturn := fake.getServiceTagsReturnsOnCall[len(fake.getServiceTagsArgsForCall)]
 	fake.getServiceTagsArgsForCall = append(fake.getServiceTagsArgsForCall, struct {
-		arg1 *aiven.GetServiceTagsInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.GetServiceTagsInput
+	}{arg1, arg2})
 	stub := fake.GetServiceTagsStub
 	fakeReturns := fake.getServiceTagsReturns
-	fake.recordInvocation("GetServiceTags", []interface{}{arg1})
+	fake.recordInvocation("GetServiceTags", []interface{}{arg1, arg2})
 	fake.getServiceTagsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -533,17 +896,17 @@ func (fake *FakeClient) GetServiceTagsCallCount() int {
 	return len(fake.getServiceTagsArgsForCall)
 }
 
-func (fake *FakeClient) GetServiceTagsCalls(stub func(*aiven.GetServiceTagsInput) (*aiven.ServiceTags, error)) {
+func (fake *FakeClient) GetServiceTagsCalls(stub func(context.Context, *aiven.GetServiceTagsInput) (*aiven.ServiceTags, error)) {
 	fake.getServiceTagsMutex.Lock()
 	defer fake.getServiceTagsMutex.Unlock()
 	fake.GetServiceTagsStub = stub
 }
 
-func (fake *FakeClient) GetServiceTagsArgsForCall(i int) *aiven.GetServiceTagsInput {
+func (fake *FakeClient) GetServiceTagsArgsForCall(i int) (context.Context, *aiven.GetServiceTagsInput) {
 	fake.getServiceTagsMutex.RLock()
 	defer fake.getServiceTagsMutex.RUnlock()
 	argsForCall := fake.getServiceTagsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) GetServiceTagsReturns(result1 *aiven.ServiceTags, result2 error) {
@@ -572,18 +935,214 @@ func (fake *FakeClient) GetServiceTagsReturnsOnCall(i int, result1 *aiven.Servic
 	}{result1, result2}
 }
 
-func (fake *FakeClient) UpdateService(arg1 *aiven.UpdateServiceInput) (string, error) {
+func (fake *FakeClient) GetServiceUser(arg1 context.Context, arg2 *aiven.GetServiceUserInput) (*aiven.User, error) {
+	fake.getServiceUserMutex.Lock()
+	ret, specificReturn := fake.getServiceUserReturnsOnCall[len(fake.getServiceUserArgsForCall)]
+	fake.getServiceUserArgsForCall = append(fake.getServiceUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 *aiven.GetServiceUserInput
+	}{arg1, arg2})
+	stub := fake.GetServiceUserStub
+	fakeReturns := fake.getServiceUserReturns
+	fake.recordInvocation("GetServiceUser", []interface{}{arg1, arg2})
+	fake.getServiceUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetServiceUserCallCount() int {
+	fake.getServiceUserMutex.RLock()
+	defer fake.getServiceUserMutex.RUnlock()
+	return len(fake.getServiceUserArgsForCall)
+}
+
+func (fake *FakeClient) GetServiceUserCalls(stub func(context.Context, *aiven.GetServiceUserInput) (*aiven.User, error)) {
+	fake.getServiceUserMutex.Lock()
+	defer fake.getServiceUserMutex.Unlock()
+	fake.GetServiceUserStub = stub
+}
+
+func (fake *FakeClient) GetServiceUserArgsForCall(i int) (context.Context, *aiven.GetServiceUserInput) {
+	fake.getServiceUserMutex.RLock()
+	defer fake.getServiceUserMutex.RUnlock()
+	argsForCall := fake.getServiceUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) GetServiceUserReturns(result1 *aiven.User, result2 error) {
+	fake.getServiceUserMutex.Lock()
+	defer fake.getServiceUserMutex.Unlock()
+	fake.GetServiceUserStub = nil
+	fake.getServiceUserReturns = struct {
+		result1 *aiven.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetServiceUserReturnsOnCall(i int, result1 *aiven.User, result2 error) {
+	fake.getServiceUserMutex.Lock()
+	defer fake.getServiceUserMutex.Unlock()
+	fake.GetServiceUserStub = nil
+	if fake.getServiceUserReturnsOnCall == nil {
+		fake.getServiceUserReturnsOnCall = make(map[int]struct {
+			result1 *aiven.User
+			result2 error
+		})
+	}
+	fake.getServiceUserReturnsOnCall[i] = struct {
+		result1 *aiven.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListKafkaACLs(arg1 context.Context, arg2 *aiven.ListKafkaACLsInput) ([]aiven.KafkaACL, error) {
+	fake.listKafkaACLsMutex.Lock()
+	ret, specificReturn := fake.listKafkaACLsReturnsOnCall[len(fake.listKafkaACLsArgsForCall)]
+	fake.listKafkaACLsArgsForCall = append(fake.listKafkaACLsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *aiven.ListKafkaACLsInput
+	}{arg1, arg2})
+	stub := fake.ListKafkaACLsStub
+	fakeReturns := fake.listKafkaACLsReturns
+	fake.recordInvocation("ListKafkaACLs", []interface{}{arg1, arg2})
+	fake.listKafkaACLsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListKafkaACLsCallCount() int {
+	fake.listKafkaACLsMutex.RLock()
+	defer fake.listKafkaACLsMutex.RUnlock()
+	return len(fake.listKafkaACLsArgsForCall)
+}
+
+func (fake *FakeClient) ListKafkaACLsCalls(stub func(context.Context, *aiven.ListKafkaACLsInput) ([]aiven.KafkaACL, error)) {
+	fake.listKafkaACLsMutex.Lock()
+	defer fake.listKafkaACLsMutex.Unlock()
+	fake.ListKafkaACLsStub = stub
+}
+
+func (fake *FakeClient) ListKafkaACLsArgsForCall(i int) (context.Context, *aiven.ListKafkaACLsInput) {
+	fake.listKafkaACLsMutex.RLock()
+	defer fake.listKafkaACLsMutex.RUnlock()
+	argsForCall := fake.listKafkaACLsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ListKafkaACLsReturns(result1 []aiven.KafkaACL, result2 error) {
+	fake.listKafkaACLsMutex.Lock()
+	defer fake.listKafkaACLsMutex.Unlock()
+	fake.ListKafkaACLsStub = nil
+	fake.listKafkaACLsReturns = struct {
+		result1 []aiven.KafkaACL
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListKafkaACLsReturnsOnCall(i int, result1 []aiven.KafkaACL, result2 error) {
+	fake.listKafkaACLsMutex.Lock()
+	defer fake.listKafkaACLsMutex.Unlock()
+	fake.ListKafkaACLsStub = nil
+	if fake.listKafkaACLsReturnsOnCall == nil {
+		fake.listKafkaACLsReturnsOnCall = make(map[int]struct {
+			result1 []aiven.KafkaACL
+			result2 error
+		})
+	}
+	fake.listKafkaACLsReturnsOnCall[i] = struct {
+		result1 []aiven.KafkaACL
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListServiceBackups(arg1 context.Context, arg2 *aiven.ListServiceBackupsInput) ([]aiven.ServiceBackup, error) {
+	fake.listServiceBackupsMutex.Lock()
+	ret, specificReturn := fake.listServiceBackupsReturnsOnCall[len(fake.listServiceBackupsArgsForCall)]
+	fake.listServiceBackupsArgsForCall = append(fake.listServiceBackupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *aiven.ListServiceBackupsInput
+	}{arg1, arg2})
+	stub := fake.ListServiceBackupsStub
+	fakeReturns := fake.listServiceBackupsReturns
+	fake.recordInvocation("ListServiceBackups", []interface{}{arg1, arg2})
+	fake.listServiceBackupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListServiceBackupsCallCount() int {
+	fake.listServiceBackupsMutex.RLock()
+	defer fake.listServiceBackupsMutex.RUnlock()
+	return len(fake.listServiceBackupsArgsForCall)
+}
+
+func (fake *FakeClient) ListServiceBackupsCalls(stub func(context.Context, *aiven.ListServiceBackupsInput) ([]aiven.ServiceBackup, error)) {
+	fake.listServiceBackupsMutex.Lock()
+	defer fake.listServiceBackupsMutex.Unlock()
+	fake.ListServiceBackupsStub = stub
+}
+
+func (fake *FakeClient) ListServiceBackupsArgsForCall(i int) (context.Context, *aiven.ListServiceBackupsInput) {
+	fake.listServiceBackupsMutex.RLock()
+	defer fake.listServiceBackupsMutex.RUnlock()
+	argsForCall := fake.listServiceBackupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ListServiceBackupsReturns(result1 []aiven.ServiceBackup, result2 error) {
+	fake.listServiceBackupsMutex.Lock()
+	defer fake.listServiceBackupsMutex.Unlock()
+	fake.ListServiceBackupsStub = nil
+	fake.listServiceBackupsReturns = struct {
+		result1 []aiven.ServiceBackup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListServiceBackupsReturnsOnCall(i int, result1 []aiven.ServiceBackup, result2 error) {
+	fake.listServiceBackupsMutex.Lock()
+	defer fake.listServiceBackupsMutex.Unlock()
+	fake.ListServiceBackupsStub = nil
+	if fake.listServiceBackupsReturnsOnCall == nil {
+		fake.listServiceBackupsReturnsOnCall = make(map[int]struct {
+			result1 []aiven.ServiceBackup
+			result2 error
+		})
+	}
+	fake.listServiceBackupsReturnsOnCall[i] = struct {
+		result1 []aiven.ServiceBackup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpdateService(arg1 context.Context, arg2 *aiven.UpdateServiceInput) (string, error) {
 	fake.updateServiceMutex.Lock()
 	ret, specificReturn := fake.updateServiceReturnsOnCall[len(fake.updateServiceArgsForCall)]
 	fake.updateServiceArgsForCall = append(fake.updateServiceArgsForCall, struct {
-		arg1 *aiven.UpdateServiceInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.UpdateServiceInput
+	}{arg1, arg2})
 	stub := fake.UpdateServiceStub
 	fakeReturns := fake.updateServiceReturns
-	fake.recordInvocation("UpdateService", []interface{}{arg1})
+	fake.recordInvocation("UpdateService", []interface{}{arg1, arg2})
 	fake.updateServiceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -597,17 +1156,17 @@ func (fake *FakeClient) UpdateServiceCallCount() int {
 	return len(fake.updateServiceArgsForCall)
 }
 
-func (fake *FakeClient) UpdateServiceCalls(stub func(*aiven.UpdateServiceInput) (string, error)) {
+func (fake *FakeClient) UpdateServiceCalls(stub func(context.Context, *aiven.UpdateServiceInput) (string, error)) {
 	fake.updateServiceMutex.Lock()
 	defer fake.updateServiceMutex.Unlock()
 	fake.UpdateServiceStub = stub
 }
 
-func (fake *FakeClient) UpdateServiceArgsForCall(i int) *aiven.UpdateServiceInput {
+func (fake *FakeClient) UpdateServiceArgsForCall(i int) (context.Context, *aiven.UpdateServiceInput) {
 	fake.updateServiceMutex.RLock()
 	defer fake.updateServiceMutex.RUnlock()
 	argsForCall := fake.updateServiceArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) UpdateServiceReturns(result1 string, result2 error) {
@@ -636,18 +1195,19 @@ func (fake *FakeClient) UpdateServiceReturnsOnCall(i int, result1 string, result
 	}{result1, result2}
 }
 
-func (fake *FakeClient) UpdateServiceTags(arg1 *aiven.UpdateServiceTagsInput) (string, error) {
+func (fake *FakeClient) UpdateServiceTags(arg1 context.Context, arg2 *aiven.UpdateServiceTagsInput) (string, error) {
 	fake.updateServiceTagsMutex.Lock()
 	ret, specificReturn := fake.updateServiceTagsReturnsOnCall[len(fake.updateServiceTagsArgsForCall)]
 	fake.updateServiceTagsArgsForCall = append(fake.updateServiceTagsArgsForCall, struct {
-		arg1 *aiven.UpdateServiceTagsInput
-	}{arg1})
+		arg1 context.Context
+		arg2 *aiven.UpdateServiceTagsInput
+	}{arg1, arg2})
 	stub := fake.UpdateServiceTagsStub
 	fakeReturns := fake.updateServiceTagsReturns
-	fake.recordInvocation("UpdateServiceTags", []interface{}{arg1})
+	fake.recordInvocation("UpdateServiceTags", []interface{}{arg1, arg2})
 	fake.updateServiceTagsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -661,17 +1221,17 @@ func (fake *FakeClient) UpdateServiceTagsCallCount() int {
 	return len(fake.updateServiceTagsArgsForCall)
 }
 
-func (fake *FakeClient) UpdateServiceTagsCalls(stub func(*aiven.UpdateServiceTagsInput) (string, error)) {
+func (fake *FakeClient) UpdateServiceTagsCalls(stub func(context.Context, *aiven.UpdateServiceTagsInput) (string, error)) {
 	fake.updateServiceTagsMutex.Lock()
 	defer fake.updateServiceTagsMutex.Unlock()
 	fake.UpdateServiceTagsStub = stub
 }
 
-func (fake *FakeClient) UpdateServiceTagsArgsForCall(i int) *aiven.UpdateServiceTagsInput {
+func (fake *FakeClient) UpdateServiceTagsArgsForCall(i int) (context.Context, *aiven.UpdateServiceTagsInput) {
 	fake.updateServiceTagsMutex.RLock()
 	defer fake.updateServiceTagsMutex.RUnlock()
 	argsForCall := fake.updateServiceTagsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeClient) UpdateServiceTagsReturns(result1 string, result2 error) {
@@ -703,20 +1263,34 @@ func (fake *FakeClient) UpdateServiceTagsReturnsOnCall(i int, result1 string, re
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createKafkaACLMutex.RLock()
+	defer fake.createKafkaACLMutex.RUnlock()
+	fake.createKafkaTopicMutex.RLock()
+	defer fake.createKafkaTopicMutex.RUnlock()
 	fake.createServiceMutex.RLock()
 	defer fake.createServiceMutex.RUnlock()
 	fake.createServiceUserMutex.RLock()
 	defer fake.createServiceUserMutex.RUnlock()
+	fake.deleteKafkaACLMutex.RLock()
+	defer fake.deleteKafkaACLMutex.RUnlock()
 	fake.deleteServiceMutex.RLock()
 	defer fake.deleteServiceMutex.RUnlock()
 	fake.deleteServiceUserMutex.RLock()
 	defer fake.deleteServiceUserMutex.RUnlock()
 	fake.forkServiceMutex.RLock()
 	defer fake.forkServiceMutex.RUnlock()
+	fake.getProjectCAMutex.RLock()
+	defer fake.getProjectCAMutex.RUnlock()
 	fake.getServiceMutex.RLock()
 	defer fake.getServiceMutex.RUnlock()
 	fake.getServiceTagsMutex.RLock()
 	defer fake.getServiceTagsMutex.RUnlock()
+	fake.getServiceUserMutex.RLock()
+	defer fake.getServiceUserMutex.RUnlock()
+	fake.listKafkaACLsMutex.RLock()
+	defer fake.listKafkaACLsMutex.RUnlock()
+	fake.listServiceBackupsMutex.RLock()
+	defer fake.listServiceBackupsMutex.RUnlock()
 	fake.updateServiceMutex.RLock()
 	defer fake.updateServiceMutex.RUnlock()
 	fake.updateServiceTagsMutex.RLock()
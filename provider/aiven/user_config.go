@@ -1,10 +1,65 @@
 package aiven
 
+import "encoding/json"
+
+// IPFilterEntry is a network in the IP filter of a service. Aiven describes
+// the entries as plain strings or as objects with a network and description.
+// Entries without a description are sent as plain strings.
+type IPFilterEntry struct {
+	Network     string `json:"network"`
+	Description string `json:"description,omitempty"`
+}
+
+func (e IPFilterEntry) MarshalJSON() ([]byte, error) {
+	if e.Description == "" {
+		return json.Marshal(e.Network)
+	}
+	type object IPFilterEntry
+	return json.Marshal(object(e))
+}
+
+func (e *IPFilterEntry) UnmarshalJSON(b []byte) error {
+	var network string
+	if err := json.Unmarshal(b, &network); err == nil {
+		*e = IPFilterEntry{Network: network}
+		return nil
+	}
+	type object IPFilterEntry
+	var entry object
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return err
+	}
+	*e = IPFilterEntry(entry)
+	return nil
+}
+
+type IPFilter []IPFilterEntry
+
+// NewIPFilter builds an IP filter of networks without descriptions
+func NewIPFilter(networks ...string) IPFilter {
+	filter := IPFilter{}
+	for _, network := range networks {
+		filter = append(filter, IPFilterEntry{Network: network})
+	}
+	return filter
+}
+
+func (f IPFilter) Networks() []string {
+	networks := []string{}
+	for _, entry := range f {
+		networks = append(networks, entry.Network)
+	}
+	return networks
+}
+
 type CommonUserConfig struct {
-	IPFilter          []string `json:"ip_filter,omitempty"`
+	IPFilter          IPFilter `json:"ip_filter,omitempty"`
 	ForkProject       string   `json:"project_to_fork_from,omitempty"`
 	BackupServiceName string   `json:"service_to_fork_from,omitempty"`
 	BackupName        string   `json:"recovery_basebackup_name,omitempty"`
+	// RecoveryTargetTime is only used by services which support point in
+	// time recovery
+	RecoveryTargetTime string `json:"recovery_target_time,omitempty"`
 }
 
 type OpenSearchUserConfig struct {
@@ -13,8 +68,29 @@ type OpenSearchUserConfig struct {
 
 type InfluxDBUserConfig struct{}
 
+type PostgreSQLUserConfig struct {
+	PostgreSQLVersion string `json:"pg_version,omitempty"`
+}
+
+type RedisUserConfig struct {
+	RedisMaxmemoryPolicy string `json:"redis_maxmemory_policy,omitempty"`
+	RedisPersistence     string `json:"redis_persistence,omitempty"`
+}
+
+type KafkaUserConfig struct {
+	KafkaVersion string `json:"kafka_version,omitempty"`
+}
+
+type MySQLUserConfig struct {
+	MySQLVersion string `json:"mysql_version,omitempty"`
+}
+
 type UserConfig struct {
 	CommonUserConfig
 	OpenSearchUserConfig
 	InfluxDBUserConfig
+	PostgreSQLUserConfig
+	RedisUserConfig
+	KafkaUserConfig
+	MySQLUserConfig
 }
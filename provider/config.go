@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/pivotal-cf/brokerapi/domain"
 )
@@ -18,6 +19,59 @@ type Config struct {
 	APIToken          string
 	Project           string
 	Catalog           Catalog `json:"catalog"`
+
+	AivenRequestTimeoutSeconds int `json:"aiven_request_timeout_seconds"`
+	AivenMaxAttempts           int `json:"aiven_max_attempts"`
+	OperationDeadlineMinutes   int `json:"operation_deadline_minutes"`
+	MaxIPFilterEntries         int `json:"max_ip_filter_entries"`
+	SoftDeleteGracePeriodHours int `json:"soft_delete_grace_period_hours"`
+
+	IPFilterPolicy IPFilterPolicy `json:"ip_filter_policy"`
+}
+
+// Aiven usually finishes within minutes, but restoring a large backup can
+// take hours
+const DefaultOperationDeadline = 6 * time.Hour
+
+func (c *Config) OperationDeadline() time.Duration {
+	if c.OperationDeadlineMinutes == 0 {
+		return DefaultOperationDeadline
+	}
+	return time.Duration(c.OperationDeadlineMinutes) * time.Minute
+}
+
+// SoftDeleteGracePeriod is how long deprovisioned services are kept powered
+// off before they are deleted. Services are deleted straight away when it is
+// zero. The broker does not delete soft deleted services itself: they are
+// tagged with `delete_after`, and a separate job must delete the services
+// whose `delete_after` time has passed.
+func (c *Config) SoftDeleteGracePeriod() time.Duration {
+	return time.Duration(c.SoftDeleteGracePeriodHours) * time.Hour
+}
+
+// IPFilterPolicyFor combines the policy of the broker with the policy of the
+// plan. The stricter of the minimum prefix lengths is used.
+func (c *Config) IPFilterPolicyFor(plan *Plan) IPFilterPolicy {
+	policy := IPFilterPolicy{
+		MinIPv4PrefixLength: c.IPFilterPolicy.MinIPv4PrefixLength,
+		MinIPv6PrefixLength: c.IPFilterPolicy.MinIPv6PrefixLength,
+	}
+	policy.MandatoryRanges = append(policy.MandatoryRanges, c.IPFilterPolicy.MandatoryRanges...)
+	policy.ForbiddenRanges = append(policy.ForbiddenRanges, c.IPFilterPolicy.ForbiddenRanges...)
+	if plan != nil {
+		policy.MandatoryRanges = append(policy.MandatoryRanges, plan.IPFilterPolicy.MandatoryRanges...)
+		policy.ForbiddenRanges = append(policy.ForbiddenRanges, plan.IPFilterPolicy.ForbiddenRanges...)
+		policy.MinIPv4PrefixLength = max(policy.MinIPv4PrefixLength, plan.IPFilterPolicy.MinIPv4PrefixLength)
+		policy.MinIPv6PrefixLength = max(policy.MinIPv6PrefixLength, plan.IPFilterPolicy.MinIPv6PrefixLength)
+	}
+	return policy
+}
+
+func (c *Config) IPFilterLimit() int {
+	if c.MaxIPFilterEntries == 0 {
+		return DefaultMaxIPFilterEntries
+	}
+	return c.MaxIPFilterEntries
 }
 
 type Catalog struct {
@@ -42,12 +96,46 @@ type AivenServiceOpenSearchConfig struct {
 
 type AivenServiceInfluxDBConfig struct{}
 
+type AivenServicePostgreSQLConfig struct {
+	PostgreSQLVersion string `json:"pg_version"`
+}
+
+type AivenServiceRedisConfig struct {
+	RedisMaxmemoryPolicy string `json:"redis_maxmemory_policy"`
+	RedisPersistence     string `json:"redis_persistence"`
+}
+
+type AivenServiceKafkaConfig struct {
+	KafkaVersion string `json:"kafka_version"`
+}
+
+type AivenServiceMySQLConfig struct {
+	MySQLVersion string `json:"mysql_version"`
+}
+
 type PlanSpecificConfig struct {
 	AivenPlan string `json:"aiven_plan"`
+	// DiskSpaceMB is the disk space which comes with the Aiven plan. It is
+	// used to check that backups fit when they are restored onto the plan.
+	DiskSpaceMB int `json:"disk_space_mb"`
+
+	IPFilterPolicy IPFilterPolicy `json:"ip_filter_policy"`
+
+	// MaintenanceWindow is used for instances which are created without
+	// a maintenance window of their own
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window"`
+
+	// TerminationProtection is used for instances which are created without
+	// asking for it to be turned on or off
+	TerminationProtection bool `json:"termination_protection"`
 
 	AivenServiceCommonConfig
 	AivenServiceOpenSearchConfig
 	AivenServiceInfluxDBConfig
+	AivenServicePostgreSQLConfig
+	AivenServiceRedisConfig
+	AivenServiceKafkaConfig
+	AivenServiceMySQLConfig
 }
 
 func DecodeConfig(b []byte) (*Config, error) {
@@ -63,6 +151,24 @@ func DecodeConfig(b []byte) (*Config, error) {
 	if config.Cloud == "" {
 		return config, errors.New("Config error: must provide cloud configuration. For example, 'aws-eu-west-1'")
 	}
+	if config.AivenRequestTimeoutSeconds < 0 {
+		return config, errors.New("Config error: `aiven_request_timeout_seconds` cannot be negative")
+	}
+	if config.AivenMaxAttempts < 0 {
+		return config, errors.New("Config error: `aiven_max_attempts` cannot be negative")
+	}
+	if config.OperationDeadlineMinutes < 0 {
+		return config, errors.New("Config error: `operation_deadline_minutes` cannot be negative")
+	}
+	if config.MaxIPFilterEntries < 0 {
+		return config, errors.New("Config error: `max_ip_filter_entries` cannot be negative")
+	}
+	if config.SoftDeleteGracePeriodHours < 0 {
+		return config, errors.New("Config error: `soft_delete_grace_period_hours` cannot be negative")
+	}
+	if err := config.IPFilterPolicy.validate(); err != nil {
+		return config, err
+	}
 	if reflect.DeepEqual(config.Catalog, Catalog{}) {
 		return config, errors.New("Config error: no catalog found")
 	}
@@ -78,9 +184,23 @@ func DecodeConfig(b []byte) (*Config, error) {
 			if plan.AivenPlan == "" {
 				return config, errors.New("Config error: every plan must specify an `aiven_plan`")
 			}
+			if plan.DiskSpaceMB < 0 {
+				return config, errors.New("Config error: `disk_space_mb` cannot be negative")
+			}
+			if err := plan.IPFilterPolicy.validate(); err != nil {
+				return config, err
+			}
+			if plan.MaintenanceWindow != nil {
+				if err := plan.MaintenanceWindow.Validate(); err != nil {
+					return config, fmt.Errorf("Config error: invalid `maintenance_window`: %s", err)
+				}
+			}
 
-			if service.Name == "opensearch" && plan.OpenSearchVersion == "" {
-				return config, errors.New("Config error: every opensearch plan must specify an `opensearch_version`")
+			// Services without a driver fail when they are provisioned
+			if driver, err := FindServiceTypeDriver(service.Name); err == nil {
+				if err := driver.ValidatePlan(plan); err != nil {
+					return config, err
+				}
 			}
 		}
 	}
@@ -130,6 +250,23 @@ func (c *Config) FindPlan(serviceId, planId string) (*Plan, error) {
 	return &plan, nil
 }
 
+func (c *Config) FindService(serviceId string) (*Service, error) {
+	service, err := findServiceById(serviceId, &c.Catalog)
+	if err != nil {
+		return &Service{}, err
+	}
+	return &service, nil
+}
+
+func (c *Config) FindServiceByPlan(planId string) (*Service, error) {
+	for _, service := range c.Catalog.Services {
+		if _, err := findPlanById(planId, service); err == nil {
+			return &service, nil
+		}
+	}
+	return &Service{}, errors.New("could not find service with plan id " + planId)
+}
+
 func findServiceById(id string, catalog *Catalog) (Service, error) {
 	for _, service := range catalog.Services {
 		if service.ID == id {
@@ -147,3 +284,12 @@ func findPlanById(id string, service Service) (Plan, error) {
 	}
 	return Plan{}, errors.New("could not find plan with id " + id)
 }
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
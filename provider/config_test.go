@@ -3,6 +3,7 @@ package provider_test
 import (
 	"encoding/json"
 	"os"
+	"time"
 
 	"github.com/alphagov/paas-aiven-broker/provider"
 	. "github.com/onsi/ginkgo/v2"
@@ -86,6 +87,69 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Context("when the Aiven API client is configured", func() {
+		It("decodes the request timeout and maximum number of attempts", func() {
+			rawConfig = json.RawMessage(`
+					{
+						"cloud": "aws-eu-west-1",
+						"aiven_request_timeout_seconds": 10,
+						"aiven_max_attempts": 5,
+						"catalog": {
+							"services": [
+								{
+									"name": "influxdb",
+									"plans": [{"aiven_plan": "startup-1"}]
+								}
+							]
+						}
+					}
+				`)
+			config, err := provider.DecodeConfig(rawConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.AivenRequestTimeoutSeconds).To(Equal(10))
+			Expect(config.AivenMaxAttempts).To(Equal(5))
+		})
+
+		It("returns an error if the maximum number of attempts is negative", func() {
+			rawConfig = json.RawMessage(`{"cloud": "aws-eu-west-1", "aiven_max_attempts": -1}`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError("Config error: `aiven_max_attempts` cannot be negative"))
+		})
+	})
+
+	Context("operation deadline", func() {
+		It("defaults the deadline when it is not set", func() {
+			config := provider.Config{}
+			Expect(config.OperationDeadline()).To(Equal(provider.DefaultOperationDeadline))
+		})
+
+		It("reads the deadline in minutes", func() {
+			rawConfig = json.RawMessage(`
+					{
+						"cloud": "aws-eu-west-1",
+						"operation_deadline_minutes": 90,
+						"catalog": {
+							"services": [
+								{
+									"name": "influxdb",
+									"plans": [{"aiven_plan": "startup-1"}]
+								}
+							]
+						}
+					}
+				`)
+			config, err := provider.DecodeConfig(rawConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.OperationDeadline()).To(Equal(90 * time.Minute))
+		})
+
+		It("returns an error if the deadline is negative", func() {
+			rawConfig = json.RawMessage(`{"cloud": "aws-eu-west-1", "operation_deadline_minutes": -1}`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError("Config error: `operation_deadline_minutes` cannot be negative"))
+		})
+	})
+
 	Context("when there is no Catalog defined", func() {
 		It("returns an error", func() {
 			rawConfig = json.RawMessage(`{"cloud": "aws-eu-west-1"}`)
@@ -94,6 +158,161 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Context("IP filter policy", func() {
+		It("combines the policy of the broker with the policy of the plan", func() {
+			plan := provider.Plan{}
+			plan.IPFilterPolicy = provider.IPFilterPolicy{
+				MandatoryRanges: []string{"203.0.113.0/24"},
+				ForbiddenRanges: []string{"10.0.0.0/8"},
+			}
+			config := provider.Config{IPFilterPolicy: provider.IPFilterPolicy{
+				MandatoryRanges: []string{"192.0.2.0/24"},
+				ForbiddenRanges: []string{"0.0.0.0/0"},
+			}}
+
+			Expect(config.IPFilterPolicyFor(&plan)).To(Equal(provider.IPFilterPolicy{
+				MandatoryRanges: []string{"192.0.2.0/24", "203.0.113.0/24"},
+				ForbiddenRanges: []string{"0.0.0.0/0", "10.0.0.0/8"},
+			}))
+		})
+
+		It("uses the stricter minimum prefix lengths", func() {
+			plan := provider.Plan{}
+			plan.IPFilterPolicy = provider.IPFilterPolicy{MinIPv4PrefixLength: 24, MinIPv6PrefixLength: 32}
+			config := provider.Config{IPFilterPolicy: provider.IPFilterPolicy{MinIPv4PrefixLength: 16, MinIPv6PrefixLength: 48}}
+
+			policy := config.IPFilterPolicyFor(&plan)
+			Expect(policy.MinIPv4PrefixLength).To(Equal(24))
+			Expect(policy.MinIPv6PrefixLength).To(Equal(48))
+		})
+
+		It("returns an error if a minimum prefix length is out of range", func() {
+			rawConfig = json.RawMessage(`{"cloud": "aws-eu-west-1", "ip_filter_policy": {"min_ipv4_prefix_length": 33}}`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError("Config error: `min_ipv4_prefix_length` must be between 0 and 32"))
+		})
+
+		It("returns an error if a range is invalid", func() {
+			rawConfig = json.RawMessage(`{"cloud": "aws-eu-west-1", "ip_filter_policy": {"forbidden_ranges": ["0.0.0.0/33"]}}`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError("Config error: invalid IP range '0.0.0.0/33' in `ip_filter_policy`"))
+		})
+
+		It("returns an error if a forbidden range covers every address", func() {
+			rawConfig = json.RawMessage(`{"cloud": "aws-eu-west-1", "ip_filter_policy": {"forbidden_ranges": ["::/0"]}}`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError(
+				"Config error: forbidden range '::/0' in `ip_filter_policy` would forbid every entry, " +
+					"use `min_ipv4_prefix_length` or `min_ipv6_prefix_length` instead",
+			))
+		})
+
+		It("returns an error if a range of a plan is invalid", func() {
+			rawConfig = json.RawMessage(`
+						{
+							"cloud": "aws-eu-west-1",
+							"catalog": {
+								"services": [
+									{
+										"name": "influxdb",
+										"plans": [{
+											"aiven_plan": "startup-1",
+											"ip_filter_policy": {"mandatory_ranges": ["egress"]}
+										}]
+									}
+								]
+							}
+						}
+					`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError("Config error: invalid IP range 'egress' in `ip_filter_policy`"))
+		})
+	})
+
+	Context("maintenance window", func() {
+		It("reads the default maintenance window of a plan", func() {
+			rawConfig = json.RawMessage(`
+						{
+							"cloud": "aws-eu-west-1",
+							"catalog": {
+								"services": [
+									{
+										"name": "influxdb",
+										"plans": [{
+											"aiven_plan": "startup-1",
+											"maintenance_window": {"dow": "sunday", "time": "03:00"}
+										}]
+									}
+								]
+							}
+						}
+					`)
+			config, err := provider.DecodeConfig(rawConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Catalog.Services[0].Plans[0].MaintenanceWindow).To(Equal(&provider.MaintenanceWindow{
+				DOW:  "sunday",
+				Time: "03:00",
+			}))
+		})
+
+		It("returns an error if the maintenance window of a plan is invalid", func() {
+			rawConfig = json.RawMessage(`
+						{
+							"cloud": "aws-eu-west-1",
+							"catalog": {
+								"services": [
+									{
+										"name": "influxdb",
+										"plans": [{
+											"aiven_plan": "startup-1",
+											"maintenance_window": {"dow": "someday", "time": "03:00"}
+										}]
+									}
+								]
+							}
+						}
+					`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError(HavePrefix("Config error: invalid `maintenance_window`: Invalid maintenance window day: 'someday'")))
+		})
+	})
+
+	Context("soft delete grace period", func() {
+		It("deletes services straight away by default", func() {
+			config := provider.Config{}
+			Expect(config.SoftDeleteGracePeriod()).To(BeZero())
+		})
+
+		It("uses the configured grace period", func() {
+			config := provider.Config{SoftDeleteGracePeriodHours: 48}
+			Expect(config.SoftDeleteGracePeriod()).To(Equal(48 * time.Hour))
+		})
+
+		It("returns an error if the grace period is negative", func() {
+			rawConfig = json.RawMessage(`{"cloud": "aws-eu-west-1", "soft_delete_grace_period_hours": -1}`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError("Config error: `soft_delete_grace_period_hours` cannot be negative"))
+		})
+	})
+
+	Context("IP filter limit", func() {
+		It("defaults to the limit of Aiven", func() {
+			config := provider.Config{}
+			Expect(config.IPFilterLimit()).To(Equal(provider.DefaultMaxIPFilterEntries))
+		})
+
+		It("uses the configured limit", func() {
+			config := provider.Config{MaxIPFilterEntries: 10}
+			Expect(config.IPFilterLimit()).To(Equal(10))
+		})
+
+		It("returns an error if the limit is negative", func() {
+			rawConfig = json.RawMessage(`{"cloud": "aws-eu-west-1", "max_ip_filter_entries": -1}`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError("Config error: `max_ip_filter_entries` cannot be negative"))
+		})
+	})
+
 	Context("when there are no services configured", func() {
 		It("returns an error", func() {
 			rawConfig = json.RawMessage(`
@@ -166,6 +385,24 @@ var _ = Describe("Config", func() {
 			Expect(err).To(MatchError("Config error: every plan must specify an `aiven_plan`"))
 		})
 
+		It("returns an error if a plan has a negative disk size", func() {
+			rawConfig = json.RawMessage(`
+						{
+							"cloud": "aws-eu-west-1",
+							"catalog": {
+								"services": [
+									{
+										"name": "influxdb",
+										"plans": [{"name": "plan-a", "aiven_plan": "startup-1", "disk_space_mb": -1}]
+									}
+								]
+							}
+						}
+					`)
+			_, err := provider.DecodeConfig(rawConfig)
+			Expect(err).To(MatchError("Config error: `disk_space_mb` cannot be negative"))
+		})
+
 		Context("when the service is opensearch", func() {
 			It("returns an error if a plan is missing the OpenSearch version", func() {
 				rawConfig = json.RawMessage(`
@@ -186,6 +423,185 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Context("when the service is pg", func() {
+			It("returns an error if a plan is missing the PostgreSQL version", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "pg",
+											"plans": [{"aiven_plan": "plan-a"}]
+										}
+									]
+								}
+							}
+						`)
+				_, err := provider.DecodeConfig(rawConfig)
+				Expect(err).To(MatchError("Config error: every pg plan must specify a `pg_version`"))
+			})
+
+			It("decodes the PostgreSQL version", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "pg",
+											"plans": [{"aiven_plan": "plan-a", "pg_version": "15"}]
+										}
+									]
+								}
+							}
+						`)
+				config, err := provider.DecodeConfig(rawConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Catalog.Services[0].Plans[0].PostgreSQLVersion).To(Equal("15"))
+			})
+		})
+
+		Context("when the service is mysql", func() {
+			It("returns an error if a plan is missing the MySQL version", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "mysql",
+											"plans": [{"aiven_plan": "plan-a"}]
+										}
+									]
+								}
+							}
+						`)
+				_, err := provider.DecodeConfig(rawConfig)
+				Expect(err).To(MatchError("Config error: every mysql plan must specify a `mysql_version`"))
+			})
+
+			It("decodes the MySQL version", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "mysql",
+											"plans": [{"aiven_plan": "plan-a", "mysql_version": "8"}]
+										}
+									]
+								}
+							}
+						`)
+				config, err := provider.DecodeConfig(rawConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Catalog.Services[0].Plans[0].MySQLVersion).To(Equal("8"))
+			})
+		})
+
+		Context("when the service is kafka", func() {
+			It("returns an error if a plan is missing the Kafka version", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "kafka",
+											"plans": [{"aiven_plan": "plan-a"}]
+										}
+									]
+								}
+							}
+						`)
+				_, err := provider.DecodeConfig(rawConfig)
+				Expect(err).To(MatchError("Config error: every kafka plan must specify a `kafka_version`"))
+			})
+
+			It("decodes the Kafka version", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "kafka",
+											"plans": [{"aiven_plan": "plan-a", "kafka_version": "3.7"}]
+										}
+									]
+								}
+							}
+						`)
+				config, err := provider.DecodeConfig(rawConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Catalog.Services[0].Plans[0].KafkaVersion).To(Equal("3.7"))
+			})
+		})
+
+		Context("when the service is redis", func() {
+			It("decodes the Redis settings", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "redis",
+											"plans": [{
+												"aiven_plan": "plan-a",
+												"redis_maxmemory_policy": "allkeys-lru",
+												"redis_persistence": "rdb"
+											}]
+										}
+									]
+								}
+							}
+						`)
+				config, err := provider.DecodeConfig(rawConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Catalog.Services[0].Plans[0].RedisMaxmemoryPolicy).To(Equal("allkeys-lru"))
+				Expect(config.Catalog.Services[0].Plans[0].RedisPersistence).To(Equal("rdb"))
+			})
+
+			It("returns an error if the maxmemory policy is invalid", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "redis",
+											"plans": [{"aiven_plan": "plan-a", "redis_maxmemory_policy": "sometimes-lru"}]
+										}
+									]
+								}
+							}
+						`)
+				_, err := provider.DecodeConfig(rawConfig)
+				Expect(err).To(MatchError("Config error: invalid `redis_maxmemory_policy` 'sometimes-lru'"))
+			})
+
+			It("returns an error if the persistence mode is invalid", func() {
+				rawConfig = json.RawMessage(`
+							{
+								"cloud": "aws-eu-west-1",
+								"catalog": {
+									"services": [
+										{
+											"name": "redis",
+											"plans": [{"aiven_plan": "plan-a", "redis_persistence": "aof"}]
+										}
+									]
+								}
+							}
+						`)
+				_, err := provider.DecodeConfig(rawConfig)
+				Expect(err).To(MatchError("Config error: invalid `redis_persistence` 'aof'"))
+			})
+		})
+
 		Context("when the service is InfluxDB", func() {
 			It("does not care about the OpenSearch version", func() {
 				rawConfig = json.RawMessage(`
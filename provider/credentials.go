@@ -3,6 +3,8 @@ package provider
 import (
 	"fmt"
 	"net/url"
+
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
 )
 
 type CommonCredentials struct {
@@ -11,6 +13,8 @@ type CommonCredentials struct {
 	Port     string `json:"port"`
 	Username string `json:"username"`
 	Password string `json:"password"`
+
+	CACertificate string `json:"ca_certificate,omitempty"`
 }
 
 type InfluxDBPrometheusBasicAuthCredentials struct {
@@ -38,12 +42,32 @@ type InfluxDBCredentials struct {
 	InfluxDBDatabase   string                         `json:"database,omitempty"`
 }
 
+type DatabaseCredentials struct {
+	Name    string `json:"name,omitempty"`
+	JDBCURI string `json:"jdbcuri,omitempty"`
+}
+
+type RedisCredentials struct {
+	TLSEnabled bool `json:"tls_enabled,omitempty"`
+}
+
+type KafkaCredentials struct {
+	BootstrapServers  string `json:"bootstrap_servers,omitempty"`
+	ClientCertificate string `json:"client_certificate,omitempty"`
+	ClientKey         string `json:"client_key,omitempty"`
+}
+
 type Credentials struct {
 	CommonCredentials
 
 	InfluxDBCredentials
+	DatabaseCredentials
+	RedisCredentials
+	KafkaCredentials
 }
 
+const defaultDatabaseName = "defaultdb"
+
 func BuildCredentials(
 	serviceType string,
 	username string,
@@ -51,28 +75,36 @@ func BuildCredentials(
 	hostname string,
 	port string,
 ) (Credentials, error) {
+	driver, err := FindServiceTypeDriver(serviceType)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return buildCredentials(driver, &aiven.User{Username: username, Password: password}, hostname, port), nil
+}
+
+func buildCredentials(
+	driver ServiceTypeDriver,
+	user *aiven.User,
+	hostname string,
+	port string,
+) Credentials {
 	credentials := Credentials{}
 
 	credentials.URI = (&url.URL{
 		Scheme: "https",
-		User:   url.UserPassword(username, password),
+		User:   url.UserPassword(user.Username, user.Password),
 		Host:   fmt.Sprintf("%s:%s", hostname, port),
 	}).String()
 
 	credentials.Port = port
 	credentials.Hostname = hostname
-	credentials.Username = username
-	credentials.Password = password
-
-	if serviceType == "opensearch" {
-		// nothing to do
-	} else if serviceType == "influxdb" {
-		addInfluxDBCredentials(&credentials)
-	} else {
-		return Credentials{}, fmt.Errorf("Unknown service type %s", serviceType)
-	}
+	credentials.Username = user.Username
+	credentials.Password = user.Password
 
-	return credentials, nil
+	driver.BuildCredentials(&credentials, user)
+
+	return credentials
 }
 
 func addInfluxDBCredentials(credentials *Credentials) {
@@ -106,3 +138,69 @@ func addInfluxDBCredentials(credentials *Credentials) {
 	}
 	credentials.InfluxDBDatabase = "defaultdb"
 }
+
+func addPostgreSQLCredentials(credentials *Credentials) {
+	credentials.URI = (&url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(credentials.Username, credentials.Password),
+		Host:     fmt.Sprintf("%s:%s", credentials.Hostname, credentials.Port),
+		Path:     "/" + defaultDatabaseName,
+		RawQuery: "sslmode=verify-full",
+	}).String()
+
+	credentials.JDBCURI = (&url.URL{
+		Scheme: "jdbc:postgresql",
+		Host:   fmt.Sprintf("%s:%s", credentials.Hostname, credentials.Port),
+		Path:   "/" + defaultDatabaseName,
+		RawQuery: url.Values{
+			"user":     []string{credentials.Username},
+			"password": []string{credentials.Password},
+			"ssl":      []string{"true"},
+			"sslmode":  []string{"verify-full"},
+		}.Encode(),
+	}).String()
+
+	credentials.Name = defaultDatabaseName
+}
+
+func addMySQLCredentials(credentials *Credentials) {
+	credentials.URI = (&url.URL{
+		Scheme:   "mysql",
+		User:     url.UserPassword(credentials.Username, credentials.Password),
+		Host:     fmt.Sprintf("%s:%s", credentials.Hostname, credentials.Port),
+		Path:     "/" + defaultDatabaseName,
+		RawQuery: "ssl-mode=REQUIRED",
+	}).String()
+
+	credentials.JDBCURI = (&url.URL{
+		Scheme: "jdbc:mysql",
+		Host:   fmt.Sprintf("%s:%s", credentials.Hostname, credentials.Port),
+		Path:   "/" + defaultDatabaseName,
+		RawQuery: url.Values{
+			"user":     []string{credentials.Username},
+			"password": []string{credentials.Password},
+			"sslMode":  []string{"REQUIRED"},
+		}.Encode(),
+	}).String()
+
+	credentials.Name = defaultDatabaseName
+}
+
+func addRedisCredentials(credentials *Credentials) {
+	credentials.URI = (&url.URL{
+		Scheme: "rediss",
+		User:   url.UserPassword(credentials.Username, credentials.Password),
+		Host:   fmt.Sprintf("%s:%s", credentials.Hostname, credentials.Port),
+	}).String()
+
+	credentials.TLSEnabled = true
+}
+
+func addKafkaCredentials(credentials *Credentials) {
+	credentials.BootstrapServers = fmt.Sprintf("%s:%s", credentials.Hostname, credentials.Port)
+
+	credentials.URI = (&url.URL{
+		Scheme: "kafka+ssl",
+		Host:   credentials.BootstrapServers,
+	}).String()
+}
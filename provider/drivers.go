@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alphagov/paas-aiven-broker/client/influxdb"
+	"github.com/alphagov/paas-aiven-broker/client/kafka"
+	"github.com/alphagov/paas-aiven-broker/client/mysql"
+	"github.com/alphagov/paas-aiven-broker/client/opensearch"
+	"github.com/alphagov/paas-aiven-broker/client/postgres"
+	"github.com/alphagov/paas-aiven-broker/client/redis"
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+)
+
+// ServiceTypeDriver holds everything which differs between the types of
+// Aiven service offered by the broker.
+type ServiceTypeDriver interface {
+	// ValidatePlan checks the service specific settings of a catalog plan
+	ValidatePlan(plan Plan) error
+	// BuildUserConfig copies the service specific settings of the plan into
+	// the user config sent to Aiven
+	BuildUserConfig(plan Plan, userConfig *aiven.UserConfig)
+	// BuildCredentials adds the service specific credentials for the user
+	BuildCredentials(credentials *Credentials, user *aiven.User)
+	// RequiresProjectCA is true when clients need the Aiven project CA to
+	// verify the service certificate
+	RequiresProjectCA() bool
+	// AvailabilityCheck returns a function which fails until the
+	// credentials can be used
+	AvailabilityCheck(credentials Credentials) (func() error, error)
+	// SupportsFork is true when Aiven can fork the service from a backup
+	SupportsFork() bool
+	// SupportsPointInTimeRestore is true when Aiven can fork the service
+	// as it was at any time within its backup retention window
+	SupportsPointInTimeRestore() bool
+	// SupportsTopics is true when topics can be created with the service
+	SupportsTopics() bool
+	// SupportsACLs is true when the access of each binding is limited by
+	// ACLs, which Aiven does not remove along with the service user
+	SupportsACLs() bool
+}
+
+// Drivers are keyed by the catalog service name, which is also the Aiven
+// service type
+var serviceTypeDrivers = map[string]ServiceTypeDriver{
+	"opensearch": openSearchDriver{},
+	"influxdb":   influxDBDriver{},
+	"pg":         postgreSQLDriver{},
+	"redis":      redisDriver{},
+	"kafka":      kafkaDriver{},
+	"mysql":      mySQLDriver{},
+}
+
+func FindServiceTypeDriver(serviceType string) (ServiceTypeDriver, error) {
+	driver, ok := serviceTypeDrivers[serviceType]
+	if !ok {
+		return nil, fmt.Errorf("Unknown service type %s", serviceType)
+	}
+	return driver, nil
+}
+
+type openSearchDriver struct{}
+
+func (openSearchDriver) ValidatePlan(plan Plan) error {
+	if plan.OpenSearchVersion == "" {
+		return errors.New("Config error: every opensearch plan must specify an `opensearch_version`")
+	}
+	return nil
+}
+
+func (openSearchDriver) BuildUserConfig(plan Plan, userConfig *aiven.UserConfig) {
+	userConfig.OpenSearchVersion = plan.OpenSearchVersion
+}
+
+func (openSearchDriver) BuildCredentials(credentials *Credentials, user *aiven.User) {}
+
+func (openSearchDriver) RequiresProjectCA() bool { return false }
+
+func (openSearchDriver) AvailabilityCheck(credentials Credentials) (func() error, error) {
+	return func() error {
+		client := opensearch.New(credentials.URI, nil)
+		_, err := client.Version()
+		return err
+	}, nil
+}
+
+func (openSearchDriver) SupportsFork() bool { return true }
+
+func (openSearchDriver) SupportsPointInTimeRestore() bool { return false }
+
+func (openSearchDriver) SupportsTopics() bool { return false }
+
+func (openSearchDriver) SupportsACLs() bool { return false }
+
+type influxDBDriver struct{}
+
+func (influxDBDriver) ValidatePlan(plan Plan) error { return nil }
+
+func (influxDBDriver) BuildUserConfig(plan Plan, userConfig *aiven.UserConfig) {}
+
+func (influxDBDriver) BuildCredentials(credentials *Credentials, user *aiven.User) {
+	addInfluxDBCredentials(credentials)
+}
+
+func (influxDBDriver) RequiresProjectCA() bool { return false }
+
+func (influxDBDriver) AvailabilityCheck(credentials Credentials) (func() error, error) {
+	return func() error {
+		client := influxdb.New(credentials.URI, nil)
+		_, err := client.Ping()
+		return err
+	}, nil
+}
+
+func (influxDBDriver) SupportsFork() bool { return false }
+
+func (influxDBDriver) SupportsPointInTimeRestore() bool { return false }
+
+func (influxDBDriver) SupportsTopics() bool { return false }
+
+func (influxDBDriver) SupportsACLs() bool { return false }
+
+type postgreSQLDriver struct{}
+
+func (postgreSQLDriver) ValidatePlan(plan Plan) error {
+	if plan.PostgreSQLVersion == "" {
+		return errors.New("Config error: every pg plan must specify a `pg_version`")
+	}
+	return nil
+}
+
+func (postgreSQLDriver) BuildUserConfig(plan Plan, userConfig *aiven.UserConfig) {
+	userConfig.PostgreSQLVersion = plan.PostgreSQLVersion
+}
+
+func (postgreSQLDriver) BuildCredentials(credentials *Credentials, user *aiven.User) {
+	addPostgreSQLCredentials(credentials)
+}
+
+func (postgreSQLDriver) RequiresProjectCA() bool { return true }
+
+func (postgreSQLDriver) AvailabilityCheck(credentials Credentials) (func() error, error) {
+	tlsConfig, err := caTLSConfig(credentials.CACertificate)
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		client := postgres.New(credentials.URI, tlsConfig)
+		return client.Ping()
+	}, nil
+}
+
+func (postgreSQLDriver) SupportsFork() bool { return true }
+
+func (postgreSQLDriver) SupportsPointInTimeRestore() bool { return true }
+
+func (postgreSQLDriver) SupportsTopics() bool { return false }
+
+func (postgreSQLDriver) SupportsACLs() bool { return false }
+
+type redisDriver struct{}
+
+var validRedisMaxmemoryPolicies = []string{
+	"noeviction",
+	"allkeys-lru",
+	"volatile-lru",
+	"allkeys-random",
+	"volatile-random",
+	"volatile-ttl",
+	"volatile-lfu",
+	"allkeys-lfu",
+}
+
+var validRedisPersistence = []string{"off", "rdb"}
+
+func (redisDriver) ValidatePlan(plan Plan) error {
+	if plan.RedisMaxmemoryPolicy != "" && !contains(validRedisMaxmemoryPolicies, plan.RedisMaxmemoryPolicy) {
+		return fmt.Errorf("Config error: invalid `redis_maxmemory_policy` '%s'", plan.RedisMaxmemoryPolicy)
+	}
+	if plan.RedisPersistence != "" && !contains(validRedisPersistence, plan.RedisPersistence) {
+		return fmt.Errorf("Config error: invalid `redis_persistence` '%s'", plan.RedisPersistence)
+	}
+	return nil
+}
+
+func (redisDriver) BuildUserConfig(plan Plan, userConfig *aiven.UserConfig) {
+	userConfig.RedisMaxmemoryPolicy = plan.RedisMaxmemoryPolicy
+	userConfig.RedisPersistence = plan.RedisPersistence
+}
+
+func (redisDriver) BuildCredentials(credentials *Credentials, user *aiven.User) {
+	addRedisCredentials(credentials)
+}
+
+func (redisDriver) RequiresProjectCA() bool { return false }
+
+func (redisDriver) AvailabilityCheck(credentials Credentials) (func() error, error) {
+	return func() error {
+		client := redis.New(credentials.URI, nil)
+		return client.Ping()
+	}, nil
+}
+
+func (redisDriver) SupportsFork() bool { return false }
+
+func (redisDriver) SupportsPointInTimeRestore() bool { return false }
+
+func (redisDriver) SupportsTopics() bool { return false }
+
+func (redisDriver) SupportsACLs() bool { return false }
+
+type kafkaDriver struct{}
+
+func (kafkaDriver) ValidatePlan(plan Plan) error {
+	if plan.KafkaVersion == "" {
+		return errors.New("Config error: every kafka plan must specify a `kafka_version`")
+	}
+	return nil
+}
+
+func (kafkaDriver) BuildUserConfig(plan Plan, userConfig *aiven.UserConfig) {
+	userConfig.KafkaVersion = plan.KafkaVersion
+}
+
+func (kafkaDriver) BuildCredentials(credentials *Credentials, user *aiven.User) {
+	addKafkaCredentials(credentials)
+	credentials.ClientCertificate = user.AccessCert
+	credentials.ClientKey = user.AccessKey
+}
+
+func (kafkaDriver) RequiresProjectCA() bool { return true }
+
+func (kafkaDriver) AvailabilityCheck(credentials Credentials) (func() error, error) {
+	tlsConfig, err := clientCertificateTLSConfig(credentials)
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		client := kafka.New(credentials.BootstrapServers, tlsConfig)
+		return client.Ping()
+	}, nil
+}
+
+func (kafkaDriver) SupportsFork() bool { return false }
+
+func (kafkaDriver) SupportsPointInTimeRestore() bool { return false }
+
+func (kafkaDriver) SupportsTopics() bool { return true }
+
+func (kafkaDriver) SupportsACLs() bool { return true }
+
+type mySQLDriver struct{}
+
+func (mySQLDriver) ValidatePlan(plan Plan) error {
+	if plan.MySQLVersion == "" {
+		return errors.New("Config error: every mysql plan must specify a `mysql_version`")
+	}
+	return nil
+}
+
+func (mySQLDriver) BuildUserConfig(plan Plan, userConfig *aiven.UserConfig) {
+	userConfig.MySQLVersion = plan.MySQLVersion
+}
+
+func (mySQLDriver) BuildCredentials(credentials *Credentials, user *aiven.User) {
+	addMySQLCredentials(credentials)
+}
+
+func (mySQLDriver) RequiresProjectCA() bool { return true }
+
+func (mySQLDriver) AvailabilityCheck(credentials Credentials) (func() error, error) {
+	tlsConfig, err := caTLSConfig(credentials.CACertificate)
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		client := mysql.New(credentials.URI, tlsConfig)
+		return client.Ping()
+	}, nil
+}
+
+func (mySQLDriver) SupportsFork() bool { return true }
+
+func (mySQLDriver) SupportsPointInTimeRestore() bool { return true }
+
+func (mySQLDriver) SupportsTopics() bool { return false }
+
+func (mySQLDriver) SupportsACLs() bool { return false }
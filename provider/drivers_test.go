@@ -0,0 +1,67 @@
+package provider_test
+
+import (
+	"github.com/alphagov/paas-aiven-broker/provider"
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceTypeDriver", func() {
+	DescribeTable("FindServiceTypeDriver",
+		func(serviceType string, requiresProjectCA bool, supportsFork bool, supportsPointInTimeRestore bool, supportsTopicsAndACLs bool) {
+			driver, err := provider.FindServiceTypeDriver(serviceType)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(driver.RequiresProjectCA()).To(Equal(requiresProjectCA))
+			Expect(driver.SupportsFork()).To(Equal(supportsFork))
+			Expect(driver.SupportsPointInTimeRestore()).To(Equal(supportsPointInTimeRestore))
+			Expect(driver.SupportsTopics()).To(Equal(supportsTopicsAndACLs))
+			Expect(driver.SupportsACLs()).To(Equal(supportsTopicsAndACLs))
+		},
+		Entry("opensearch", "opensearch", false, true, false, false),
+		Entry("influxdb", "influxdb", false, false, false, false),
+		Entry("pg", "pg", true, true, true, false),
+		Entry("redis", "redis", false, false, false, false),
+		Entry("kafka", "kafka", true, false, false, true),
+		Entry("mysql", "mysql", true, true, true, false),
+	)
+
+	It("returns an error for unknown service types", func() {
+		_, err := provider.FindServiceTypeDriver("cassandra")
+		Expect(err).To(MatchError("Unknown service type cassandra"))
+	})
+
+	It("only sets the settings of its own service type in the user config", func() {
+		plan := provider.Plan{}
+		plan.OpenSearchVersion = "2"
+		plan.PostgreSQLVersion = "15"
+
+		driver, err := provider.FindServiceTypeDriver("pg")
+		Expect(err).NotTo(HaveOccurred())
+
+		userConfig := aiven.UserConfig{}
+		driver.BuildUserConfig(plan, &userConfig)
+
+		expectedUserConfig := aiven.UserConfig{}
+		expectedUserConfig.PostgreSQLVersion = "15"
+		Expect(userConfig).To(Equal(expectedUserConfig))
+	})
+
+	It("adds the client certificate of the user to kafka credentials", func() {
+		driver, err := provider.FindServiceTypeDriver("kafka")
+		Expect(err).NotTo(HaveOccurred())
+
+		credentials := provider.Credentials{}
+		credentials.Hostname = "kafka.aiven.io"
+		credentials.Port = "2705"
+		driver.BuildCredentials(&credentials, &aiven.User{
+			AccessCert: "client-cert",
+			AccessKey:  "client-key",
+		})
+
+		Expect(credentials.BootstrapServers).To(Equal("kafka.aiven.io:2705"))
+		Expect(credentials.ClientCertificate).To(Equal("client-cert"))
+		Expect(credentials.ClientKey).To(Equal("client-key"))
+	})
+})
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+	"github.com/pivotal-cf/brokerapi/domain/apiresponses"
+)
+
+// ErrInvalidParameters is returned when the parameters given by the user
+// cannot be used. Its message is shown to the user, so it should explain
+// what to change without revealing anything about the broker's internals.
+type ErrInvalidParameters struct {
+	Message string
+}
+
+func (e ErrInvalidParameters) Error() string {
+	return e.Message
+}
+
+func invalidParameters(format string, a ...interface{}) error {
+	return ErrInvalidParameters{Message: fmt.Sprintf(format, a...)}
+}
+
+// aivenFailure is a failure response for an error returned by Aiven. Users
+// only see the fixed description of the failure response, whereas Error
+// returns the Aiven error so that the broker logs its full detail.
+type aivenFailure struct {
+	*apiresponses.FailureResponse
+	err error
+}
+
+func newAivenFailure(err error, description string, statusCode int, loggerAction, errorKey string) error {
+	return aivenFailure{
+		FailureResponse: apiresponses.NewFailureResponseBuilder(
+			errors.New(description), statusCode, loggerAction,
+		).WithErrorKey(errorKey).Build(),
+		err: err,
+	}
+}
+
+func (f aivenFailure) Error() string {
+	return f.err.Error()
+}
+
+func (f aivenFailure) Unwrap() []error {
+	return []error{f.FailureResponse, f.err}
+}
+
+// AivenFailureResponse turns Aiven errors which the platform can act upon
+// into failure responses with a matching status code, and leaves any other
+// errors to be reported as internal server errors.
+func AivenFailureResponse(err error) error {
+	if !errors.As(err, new(*aiven.APIError)) {
+		return err
+	}
+	switch {
+	case aiven.IsQuotaExceeded(err):
+		return newAivenFailure(err,
+			"The Aiven project has run out of quota, please contact support",
+			http.StatusUnprocessableEntity, "aiven-quota-exceeded", "QuotaExceeded",
+		)
+	case aiven.IsConflict(err):
+		return newAivenFailure(err,
+			"The request conflicts with the current state of the service in Aiven, please try again later",
+			http.StatusConflict, "aiven-conflict", "Conflict",
+		)
+	case aiven.IsUnavailable(err):
+		return newAivenFailure(err,
+			"Aiven is temporarily unavailable, please try again later",
+			http.StatusServiceUnavailable, "aiven-unavailable", "ServiceUnavailable",
+		)
+	}
+	return err
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type FakeServiceProvider struct {
-	BindStub        func(context.Context, provider.BindData) (domain.Binding, error)
+	BindStub        func(context.Context, provider.BindData, bool) (domain.Binding, error)
 	bindMutex       sync.RWMutex
 	bindArgsForCall []struct {
 		arg1 context.Context
 		arg2 provider.BindData
+		arg3 bool
 	}
 	bindReturns struct {
 		result1 domain.Binding
@@ -62,6 +63,50 @@ type FakeServiceProvider struct {
 		result1 string
 		result2 error
 	}
+	GetBindingStub        func(context.Context, provider.GetBindingData) (domain.GetBindingSpec, error)
+	getBindingMutex       sync.RWMutex
+	getBindingArgsForCall []struct {
+		arg1 context.Context
+		arg2 provider.GetBindingData
+	}
+	getBindingReturns struct {
+		result1 domain.GetBindingSpec
+		result2 error
+	}
+	getBindingReturnsOnCall map[int]struct {
+		result1 domain.GetBindingSpec
+		result2 error
+	}
+	GetInstanceStub        func(context.Context, provider.GetInstanceData) (domain.GetInstanceDetailsSpec, error)
+	getInstanceMutex       sync.RWMutex
+	getInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 provider.GetInstanceData
+	}
+	getInstanceReturns struct {
+		result1 domain.GetInstanceDetailsSpec
+		result2 error
+	}
+	getInstanceReturnsOnCall map[int]struct {
+		result1 domain.GetInstanceDetailsSpec
+		result2 error
+	}
+	LastBindingOperationStub        func(context.Context, provider.LastBindingOperationData) (domain.LastOperationState, string, error)
+	lastBindingOperationMutex       sync.RWMutex
+	lastBindingOperationArgsForCall []struct {
+		arg1 context.Context
+		arg2 provider.LastBindingOperationData
+	}
+	lastBindingOperationReturns struct {
+		result1 domain.LastOperationState
+		result2 string
+		result3 error
+	}
+	lastBindingOperationReturnsOnCall map[int]struct {
+		result1 domain.LastOperationState
+		result2 string
+		result3 error
+	}
 	LastOperationStub        func(context.Context, provider.LastOperationData) (domain.LastOperationState, string, error)
 	lastOperationMutex       sync.RWMutex
 	lastOperationArgsForCall []struct {
@@ -78,6 +123,20 @@ type FakeServiceProvider struct {
 		result2 string
 		result3 error
 	}
+	ListBackupsStub        func(context.Context, provider.ListBackupsData) ([]provider.Backup, error)
+	listBackupsMutex       sync.RWMutex
+	listBackupsArgsForCall []struct {
+		arg1 context.Context
+		arg2 provider.ListBackupsData
+	}
+	listBackupsReturns struct {
+		result1 []provider.Backup
+		result2 error
+	}
+	listBackupsReturnsOnCall map[int]struct {
+		result1 []provider.Backup
+		result2 error
+	}
 	ProvisionStub        func(context.Context, provider.ProvisionData, bool) (domain.ProvisionedServiceSpec, error)
 	provisionMutex       sync.RWMutex
 	provisionArgsForCall []struct {
@@ -124,19 +183,20 @@ type FakeServiceProvider struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeServiceProvider) Bind(arg1 context.Context, arg2 provider.BindData) (domain.Binding, error) {
+func (fake *FakeServiceProvider) Bind(arg1 context.Context, arg2 provider.BindData, arg3 bool) (domain.Binding, error) {
 	fake.bindMutex.Lock()
 	ret, specificReturn := fake.bindReturnsOnCall[len(fake.bindArgsForCall)]
 	fake.bindArgsForCall = append(fake.bindArgsForCall, struct {
 		arg1 context.Context
 		arg2 provider.BindData
-	}{arg1, arg2})
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.BindStub
 	fakeReturns := fake.bindReturns
-	fake.recordInvocation("Bind", []interface{}{arg1, arg2})
+	fake.recordInvocation("Bind", []interface{}{arg1, arg2, arg3})
 	fake.bindMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -150,17 +210,17 @@ func (fake *FakeServiceProvider) BindCallCount() int {
 	return len(fake.bindArgsForCall)
 }
 
-func (fake *FakeServiceProvider) BindCalls(stub func(context.Context, provider.BindData) (domain.Binding, error)) {
+func (fake *FakeServiceProvider) BindCalls(stub func(context.Context, provider.BindData, bool) (domain.Binding, error)) {
 	fake.bindMutex.Lock()
 	defer fake.bindMutex.Unlock()
 	fake.BindStub = stub
 }
 
-func (fake *FakeServiceProvider) BindArgsForCall(i int) (context.Context, provider.BindData) {
+func (fake *FakeServiceProvider) BindArgsForCall(i int) (context.Context, provider.BindData, bool) {
 	fake.bindMutex.RLock()
 	defer fake.bindMutex.RUnlock()
 	argsForCall := fake.bindArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeServiceProvider) BindReturns(result1 domain.Binding, result2 error) {
@@ -377,6 +437,204 @@ func (fake *FakeServiceProvider) DeprovisionReturnsOnCall(i int, result1 string,
 	}{result1, result2}
 }
 
+func (fake *FakeServiceProvider) GetBinding(arg1 context.Context, arg2 provider.GetBindingData) (domain.GetBindingSpec, error) {
+	fake.getBindingMutex.Lock()
+	ret, specificReturn := fake.getBindingReturnsOnCall[len(fake.getBindingArgsForCall)]
+	fake.getBindingArgsForCall = append(fake.getBindingArgsForCall, struct {
+		arg1 context.Context
+		arg2 provider.GetBindingData
+	}{arg1, arg2})
+	stub := fake.GetBindingStub
+	fakeReturns := fake.getBindingReturns
+	fake.recordInvocation("GetBinding", []interface{}{arg1, arg2})
+	fake.getBindingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeServiceProvider) GetBindingCallCount() int {
+	fake.getBindingMutex.RLock()
+	defer fake.getBindingMutex.RUnlock()
+	return len(fake.getBindingArgsForCall)
+}
+
+func (fake *FakeServiceProvider) GetBindingCalls(stub func(context.Context, provider.GetBindingData) (domain.GetBindingSpec, error)) {
+	fake.getBindingMutex.Lock()
+	defer fake.getBindingMutex.Unlock()
+	fake.GetBindingStub = stub
+}
+
+func (fake *FakeServiceProvider) GetBindingArgsForCall(i int) (context.Context, provider.GetBindingData) {
+	fake.getBindingMutex.RLock()
+	defer fake.getBindingMutex.RUnlock()
+	argsForCall := fake.getBindingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeServiceProvider) GetBindingReturns(result1 domain.GetBindingSpec, result2 error) {
+	fake.getBindingMutex.Lock()
+	defer fake.getBindingMutex.Unlock()
+	fake.GetBindingStub = nil
+	fake.getBindingReturns = struct {
+		result1 domain.GetBindingSpec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeServiceProvider) GetBindingReturnsOnCall(i int, result1 domain.GetBindingSpec, result2 error) {
+	fake.getBindingMutex.Lock()
+	defer fake.getBindingMutex.Unlock()
+	fake.GetBindingStub = nil
+	if fake.getBindingReturnsOnCall == nil {
+		fake.getBindingReturnsOnCall = make(map[int]struct {
+			result1 domain.GetBindingSpec
+			result2 error
+		})
+	}
+	fake.getBindingReturnsOnCall[i] = struct {
+		result1 domain.GetBindingSpec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeServiceProvider) GetInstance(arg1 context.Context, arg2 provider.GetInstanceData) (domain.GetInstanceDetailsSpec, error) {
+	fake.getInstanceMutex.Lock()
+	ret, specificReturn := fake.getInstanceReturnsOnCall[len(fake.getInstanceArgsForCall)]
+	fake.getInstanceArgsForCall = append(fake.getInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 provider.GetInstanceData
+	}{arg1, arg2})
+	stub := fake.GetInstanceStub
+	fakeReturns := fake.getInstanceReturns
+	fake.recordInvocation("GetInstance", []interface{}{arg1, arg2})
+	fake.getInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeServiceProvider) GetInstanceCallCount() int {
+	fake.getInstanceMutex.RLock()
+	defer fake.getInstanceMutex.RUnlock()
+	return len(fake.getInstanceArgsForCall)
+}
+
+func (fake *FakeServiceProvider) GetInstanceCalls(stub func(context.Context, provider.GetInstanceData) (domain.GetInstanceDetailsSpec, error)) {
+	fake.getInstanceMutex.Lock()
+	defer fake.getInstanceMutex.Unlock()
+	fake.GetInstanceStub = stub
+}
+
+func (fake *FakeServiceProvider) GetInstanceArgsForCall(i int) (context.Context, provider.GetInstanceData) {
+	fake.getInstanceMutex.RLock()
+	defer fake.getInstanceMutex.RUnlock()
+	argsForCall := fake.getInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeServiceProvider) GetInstanceReturns(result1 domain.GetInstanceDetailsSpec, result2 error) {
+	fake.getInstanceMutex.Lock()
+	defer fake.getInstanceMutex.Unlock()
+	fake.GetInstanceStub = nil
+	fake.getInstanceReturns = struct {
+		result1 domain.GetInstanceDetailsSpec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeServiceProvider) GetInstanceReturnsOnCall(i int, result1 domain.GetInstanceDetailsSpec, result2 error) {
+	fake.getInstanceMutex.Lock()
+	defer fake.getInstanceMutex.Unlock()
+	fake.GetInstanceStub = nil
+	if fake.getInstanceReturnsOnCall == nil {
+		fake.getInstanceReturnsOnCall = make(map[int]struct {
+			result1 domain.GetInstanceDetailsSpec
+			result2 error
+		})
+	}
+	fake.getInstanceReturnsOnCall[i] = struct {
+		result1 domain.GetInstanceDetailsSpec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeServiceProvider) LastBindingOperation(arg1 context.Context, arg2 provider.LastBindingOperationData) (domain.LastOperationState, string, error) {
+	fake.lastBindingOperationMutex.Lock()
+	ret, specificReturn := fake.lastBindingOperationReturnsOnCall[len(fake.lastBindingOperationArgsForCall)]
+	fake.lastBindingOperationArgsForCall = append(fake.lastBindingOperationArgsForCall, struct {
+		arg1 context.Context
+		arg2 provider.LastBindingOperationData
+	}{arg1, arg2})
+	stub := fake.LastBindingOperationStub
+	fakeReturns := fake.lastBindingOperationReturns
+	fake.recordInvocation("LastBindingOperation", []interface{}{arg1, arg2})
+	fake.lastBindingOperationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeServiceProvider) LastBindingOperationCallCount() int {
+	fake.lastBindingOperationMutex.RLock()
+	defer fake.lastBindingOperationMutex.RUnlock()
+	return len(fake.lastBindingOperationArgsForCall)
+}
+
+func (fake *FakeServiceProvider) LastBindingOperationCalls(stub func(context.Context, provider.LastBindingOperationData) (domain.LastOperationState, string, error)) {
+	fake.lastBindingOperationMutex.Lock()
+	defer fake.lastBindingOperationMutex.Unlock()
+	fake.LastBindingOperationStub = stub
+}
+
+func (fake *FakeServiceProvider) LastBindingOperationArgsForCall(i int) (context.Context, provider.LastBindingOperationData) {
+	fake.lastBindingOperationMutex.RLock()
+	defer fake.lastBindingOperationMutex.RUnlock()
+	argsForCall := fake.lastBindingOperationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeServiceProvider) LastBindingOperationReturns(result1 domain.LastOperationState, result2 string, result3 error) {
+	fake.lastBindingOperationMutex.Lock()
+	defer fake.lastBindingOperationMutex.Unlock()
+	fake.LastBindingOperationStub = nil
+	fake.lastBindingOperationReturns = struct {
+		result1 domain.LastOperationState
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeServiceProvider) LastBindingOperationReturnsOnCall(i int, result1 domain.LastOperationState, result2 string, result3 error) {
+	fake.lastBindingOperationMutex.Lock()
+	defer fake.lastBindingOperationMutex.Unlock()
+	fake.LastBindingOperationStub = nil
+	if fake.lastBindingOperationReturnsOnCall == nil {
+		fake.lastBindingOperationReturnsOnCall = make(map[int]struct {
+			result1 domain.LastOperationState
+			result2 string
+			result3 error
+		})
+	}
+	fake.lastBindingOperationReturnsOnCall[i] = struct {
+		result1 domain.LastOperationState
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeServiceProvider) LastOperation(arg1 context.Context, arg2 provider.LastOperationData) (domain.LastOperationState, string, error) {
 	fake.lastOperationMutex.Lock()
 	ret, specificReturn := fake.lastOperationReturnsOnCall[len(fake.lastOperationArgsForCall)]
@@ -445,6 +703,71 @@ func (fake *FakeServiceProvider) LastOperationReturnsOnCall(i int, result1 domai
 	}{result1, result2, result3}
 }
 
+func (fake *FakeServiceProvider) ListBackups(arg1 context.Context, arg2 provider.ListBackupsData) ([]provider.Backup, error) {
+	fake.listBackupsMutex.Lock()
+	ret, specificReturn := fake.listBackupsReturnsOnCall[len(fake.listBackupsArgsForCall)]
+	fake.listBackupsArgsForCall = append(fake.listBackupsArgsForCall, struct {
+		arg1 context.Context
+		arg2 provider.ListBackupsData
+	}{arg1, arg2})
+	stub := fake.ListBackupsStub
+	fakeReturns := fake.listBackupsReturns
+	fake.recordInvocation("ListBackups", []interface{}{arg1, arg2})
+	fake.listBackupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeServiceProvider) ListBackupsCallCount() int {
+	fake.listBackupsMutex.RLock()
+	defer fake.listBackupsMutex.RUnlock()
+	return len(fake.listBackupsArgsForCall)
+}
+
+func (fake *FakeServiceProvider) ListBackupsCalls(stub func(context.Context, provider.ListBackupsData) ([]provider.Backup, error)) {
+	fake.listBackupsMutex.Lock()
+	defer fake.listBackupsMutex.Unlock()
+	fake.ListBackupsStub = stub
+}
+
+func (fake *FakeServiceProvider) ListBackupsArgsForCall(i int) (context.Context, provider.ListBackupsData) {
+	fake.listBackupsMutex.RLock()
+	defer fake.listBackupsMutex.RUnlock()
+	argsForCall := fake.listBackupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeServiceProvider) ListBackupsReturns(result1 []provider.Backup, result2 error) {
+	fake.listBackupsMutex.Lock()
+	defer fake.listBackupsMutex.Unlock()
+	fake.ListBackupsStub = nil
+	fake.listBackupsReturns = struct {
+		result1 []provider.Backup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeServiceProvider) ListBackupsReturnsOnCall(i int, result1 []provider.Backup, result2 error) {
+	fake.listBackupsMutex.Lock()
+	defer fake.listBackupsMutex.Unlock()
+	fake.ListBackupsStub = nil
+	if fake.listBackupsReturnsOnCall == nil {
+		fake.listBackupsReturnsOnCall = make(map[int]struct {
+			result1 []provider.Backup
+			result2 error
+		})
+	}
+	fake.listBackupsReturnsOnCall[i] = struct {
+		result1 []provider.Backup
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeServiceProvider) Provision(arg1 context.Context, arg2 provider.ProvisionData, arg3 bool) (domain.ProvisionedServiceSpec, error) {
 	fake.provisionMutex.Lock()
 	ret, specificReturn := fake.provisionReturnsOnCall[len(fake.provisionArgsForCall)]
@@ -650,8 +973,16 @@ func (fake *FakeServiceProvider) Invocations() map[string][][]interface{} {
 	defer fake.checkPermissionsFromTagsMutex.RUnlock()
 	fake.deprovisionMutex.RLock()
 	defer fake.deprovisionMutex.RUnlock()
+	fake.getBindingMutex.RLock()
+	defer fake.getBindingMutex.RUnlock()
+	fake.getInstanceMutex.RLock()
+	defer fake.getInstanceMutex.RUnlock()
+	fake.lastBindingOperationMutex.RLock()
+	defer fake.lastBindingOperationMutex.RUnlock()
 	fake.lastOperationMutex.RLock()
 	defer fake.lastOperationMutex.RUnlock()
+	fake.listBackupsMutex.RLock()
+	defer fake.listBackupsMutex.RUnlock()
 	fake.provisionMutex.RLock()
 	defer fake.provisionMutex.RUnlock()
 	fake.unbindMutex.RLock()
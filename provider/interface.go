@@ -12,10 +12,14 @@ import (
 type ServiceProvider interface {
 	Provision(context.Context, ProvisionData, bool) (result domain.ProvisionedServiceSpec, err error)
 	Deprovision(context.Context, DeprovisionData) (operationData string, err error)
-	Bind(context.Context, BindData) (binding domain.Binding, err error)
+	Bind(context.Context, BindData, bool) (binding domain.Binding, err error)
 	Unbind(context.Context, UnbindData) (err error)
+	GetBinding(context.Context, GetBindingData) (spec domain.GetBindingSpec, err error)
+	LastBindingOperation(context.Context, LastBindingOperationData) (state domain.LastOperationState, description string, err error)
 	Update(context.Context, UpdateData, bool) (result domain.UpdateServiceSpec, err error)
 	LastOperation(context.Context, LastOperationData) (state domain.LastOperationState, description string, err error)
+	GetInstance(context.Context, GetInstanceData) (spec domain.GetInstanceDetailsSpec, err error)
+	ListBackups(context.Context, ListBackupsData) (backups []Backup, err error)
 	BuildServiceName(guid string) (serviceName string)
 	CheckPermissionsFromTags(details domain.ProvisionDetails, tags *aiven.ServiceTags) (err error)
 }
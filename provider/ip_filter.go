@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+)
+
+// Aiven rejects IP filters with more entries than this
+const DefaultMaxIPFilterEntries = 1024
+
+// AivenDefaultIPFilter is the IP filter Aiven gives services which were
+// created without one
+const AivenDefaultIPFilter = "0.0.0.0/0"
+
+func ParseIPWhitelist(ips string) ([]string, error) {
+	return ParseIPFilter(ips, DefaultMaxIPFilterEntries)
+}
+
+// ParseIPFilter parses a comma separated list of IPv4 and IPv6 addresses and
+// prefixes. Entries are canonicalised, so that the same network written in
+// different ways is only sent to Aiven once, and prefixes which cover a
+// single address are written as that address.
+func ParseIPFilter(ips string, maxEntries int) ([]string, error) {
+	filter := []string{}
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(ips, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		network, err := canonicalNetwork(entry)
+		if err != nil {
+			return []string{}, err
+		}
+		if seen[network] {
+			continue
+		}
+		seen[network] = true
+		filter = append(filter, network)
+	}
+	if maxEntries > 0 && len(filter) > maxEntries {
+		return []string{}, invalidParameters(
+			"IP filter has %d entries but at most %d are allowed", len(filter), maxEntries,
+		)
+	}
+	return filter, nil
+}
+
+func canonicalNetwork(entry string) (string, error) {
+	network, err := parseNetwork(entry)
+	if err != nil {
+		return "", err
+	}
+	if network.IsSingleIP() {
+		return network.Addr().String(), nil
+	}
+	return network.String(), nil
+}
+
+// parseNetwork parses an address or prefix, treating an address as a prefix
+// which covers only that address
+func parseNetwork(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return netip.Prefix{}, invalidParameters("Invalid IP filter entry '%s': not a valid CIDR prefix", entry)
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(entry)
+	if err != nil || addr.Zone() != "" {
+		return netip.Prefix{}, invalidParameters("Invalid IP filter entry '%s': not a valid IPv4 or IPv6 address", entry)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// IPFilterPolicy lets operators control the IP filters of services. Mandatory
+// ranges, such as the egress ranges of the platform, are added to the IP
+// filter of every service. User supplied entries may not overlap a forbidden
+// range, such as the link local range of the cloud provider.
+//
+// Forbidding 0.0.0.0/0 or ::/0 would forbid every entry, so the config is
+// rejected if it does. Wide open IP filters are prevented with minimum prefix
+// lengths instead, which together with the maximum number of entries limit
+// how much of the internet a service can be opened to.
+type IPFilterPolicy struct {
+	MandatoryRanges     []string `json:"mandatory_ranges"`
+	ForbiddenRanges     []string `json:"forbidden_ranges"`
+	MinIPv4PrefixLength int      `json:"min_ipv4_prefix_length"`
+	MinIPv6PrefixLength int      `json:"min_ipv6_prefix_length"`
+}
+
+func (p IPFilterPolicy) validate() error {
+	for _, ranges := range [][]string{p.MandatoryRanges, p.ForbiddenRanges} {
+		for _, entry := range ranges {
+			if _, err := parseNetwork(entry); err != nil {
+				return fmt.Errorf("Config error: invalid IP range '%s' in `ip_filter_policy`", entry)
+			}
+		}
+	}
+	for _, entry := range p.ForbiddenRanges {
+		if network, _ := parseNetwork(entry); network.Bits() == 0 {
+			return fmt.Errorf(
+				"Config error: forbidden range '%s' in `ip_filter_policy` would forbid every entry, "+
+					"use `min_ipv4_prefix_length` or `min_ipv6_prefix_length` instead", entry,
+			)
+		}
+	}
+	if p.MinIPv4PrefixLength < 0 || p.MinIPv4PrefixLength > 32 {
+		return fmt.Errorf("Config error: `min_ipv4_prefix_length` must be between 0 and 32")
+	}
+	if p.MinIPv6PrefixLength < 0 || p.MinIPv6PrefixLength > 128 {
+		return fmt.Errorf("Config error: `min_ipv6_prefix_length` must be between 0 and 128")
+	}
+	return nil
+}
+
+// restrictsUserEntries is true when the policy would reject Aiven's default
+// IP filter, which lets every address connect
+func (p IPFilterPolicy) restrictsUserEntries() bool {
+	return len(p.ForbiddenRanges) > 0 || p.MinIPv4PrefixLength > 0 || p.MinIPv6PrefixLength > 0
+}
+
+// check returns an error naming the first user supplied entry which
+// overlaps a forbidden range or is wider than the minimum prefix length
+func (p IPFilterPolicy) check(userFilter []string) error {
+	for _, entry := range userFilter {
+		network, err := parseNetwork(entry)
+		if err != nil {
+			return err
+		}
+		minPrefixLength := p.MinIPv4PrefixLength
+		if network.Addr().Is6() {
+			minPrefixLength = p.MinIPv6PrefixLength
+		}
+		if network.Bits() < minPrefixLength {
+			return invalidParameters(
+				"IP filter entry '%s' is not allowed because it is wider than /%d",
+				entry, minPrefixLength,
+			)
+		}
+		for _, forbiddenRange := range p.ForbiddenRanges {
+			forbidden, err := parseNetwork(forbiddenRange)
+			if err != nil {
+				return err
+			}
+			if network.Overlaps(forbidden) {
+				return invalidParameters(
+					"IP filter entry '%s' is not allowed because it overlaps the forbidden range %s",
+					entry, forbidden,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// buildIPFilter puts the mandatory ranges of the plan in front of the IP
+// filter requested by the user. The user's entries are only checked against
+// the policy of the plan when checkPolicy is set, so that entries which were
+// already in place before the policy applied to them can be kept.
+func (ap *AivenProvider) buildIPFilter(plan *Plan, userIPFilter string, checkPolicy bool) ([]string, error) {
+	userFilter, err := ParseIPFilter(userIPFilter, 0)
+	if err != nil {
+		return []string{}, err
+	}
+	policy := ap.Config.IPFilterPolicyFor(plan)
+	mandatoryFilter, err := ap.mandatoryIPFilter(plan)
+	if err != nil {
+		return []string{}, err
+	}
+
+	// The mandatory ranges are exempt from the forbidden ranges, even when
+	// the user asks for them too
+	userEntries := []string{}
+	for _, entry := range userFilter {
+		if !contains(mandatoryFilter, entry) {
+			userEntries = append(userEntries, entry)
+		}
+	}
+	if checkPolicy {
+		if err := policy.check(userEntries); err != nil {
+			return []string{}, err
+		}
+	}
+
+	entries := append(mandatoryFilter, userEntries...)
+	filter, err := ParseIPFilter(strings.Join(entries, ","), ap.Config.IPFilterLimit())
+	if err != nil {
+		return []string{}, err
+	}
+
+	// Aiven allows connections from anywhere when the IP filter is empty
+	if len(filter) == 0 && policy.restrictsUserEntries() {
+		return []string{}, invalidParameters("Parameter ip_filter is required for this plan")
+	}
+	return filter, nil
+}
+
+// mandatoryIPFilter returns the ranges which the broker adds to the IP filter
+// of every service on the plan: the IP_WHITELIST and the mandatory ranges of
+// the policy
+func (ap *AivenProvider) mandatoryIPFilter(plan *Plan) ([]string, error) {
+	policy := ap.Config.IPFilterPolicyFor(plan)
+	return ParseIPFilter(
+		strings.Join(append([]string{IPAddresses("")}, policy.MandatoryRanges...), ","), 0,
+	)
+}
+
+// userIPFilterEntries leaves the ranges which the broker added for any of the
+// plans out of the current IP filter of a service, so that they are not
+// carried over to a new filter as if the user had asked for them
+func (ap *AivenProvider) userIPFilterEntries(current aiven.IPFilter, plans ...*Plan) ([]string, error) {
+	mandatoryFilter := []string{}
+	for _, plan := range plans {
+		filter, err := ap.mandatoryIPFilter(plan)
+		if err != nil {
+			return []string{}, err
+		}
+		mandatoryFilter = append(mandatoryFilter, filter...)
+	}
+
+	filter, err := ParseIPFilter(strings.Join(current.Networks(), ","), 0)
+	if err != nil {
+		return []string{}, err
+	}
+	entries := []string{}
+	for _, entry := range filter {
+		if !contains(mandatoryFilter, entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// withDescriptions turns the networks into an IP filter which keeps the
+// descriptions of the networks in the current IP filter
+func withDescriptions(networks []string, current aiven.IPFilter) aiven.IPFilter {
+	descriptions := map[string]string{}
+	for _, entry := range current {
+		network, err := canonicalNetwork(entry.Network)
+		if err == nil && entry.Description != "" {
+			descriptions[network] = entry.Description
+		}
+	}
+	filter := aiven.IPFilter{}
+	for _, network := range networks {
+		filter = append(filter, aiven.IPFilterEntry{Network: network, Description: descriptions[network]})
+	}
+	return filter
+}
@@ -0,0 +1,113 @@
+package provider_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alphagov/paas-aiven-broker/provider"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("The ParseIPWhitelist function", func() {
+	It("parses an empty string as an empty list", func() {
+		Expect(provider.ParseIPWhitelist("")).
+			To(BeEmpty())
+	})
+
+	It("parses a single IP", func() {
+		Expect(provider.ParseIPWhitelist("127.0.0.1")).
+			To(Equal([]string{"127.0.0.1"}))
+	})
+
+	It("parses multiple IPs", func() {
+		Expect(provider.ParseIPWhitelist("127.0.0.1,99.99.99.99")).
+			To(Equal([]string{"127.0.0.1", "99.99.99.99"}))
+	})
+
+	It("returns an error for IPs containing the wrong number of octets", func() {
+		var err error
+		By("not permitting too many octets")
+		_, err = provider.ParseIPWhitelist("127.0.0.0.1")
+		Expect(err).To(HaveOccurred())
+		By("not permitting too few octets")
+		_, err = provider.ParseIPWhitelist("127.0.1")
+		Expect(err).To(HaveOccurred())
+		By("not permitting too few octets even when valid IPs are present")
+		_, err = provider.ParseIPWhitelist("8.8.8.8,127.0.1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns an error for garbage IPs", func() {
+		_, err := provider.ParseIPWhitelist("ojnratuh53ggijntboijngk3,0ij90490ti9jo43p;';;1;'")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("parses IPv6 addresses and prefixes", func() {
+		Expect(provider.ParseIPWhitelist("2001:db8::1,2001:db8::/32")).
+			To(Equal([]string{"2001:db8::1", "2001:db8::/32"}))
+	})
+
+	DescribeTable("canonicalises entries",
+		func(entry, expected string) {
+			Expect(provider.ParseIPWhitelist(entry)).To(Equal([]string{expected}))
+		},
+		Entry("IPv4 prefix", "10.0.0.0/8", "10.0.0.0/8"),
+		Entry("IPv4 prefix with host bits", "10.1.2.3/8", "10.0.0.0/8"),
+		Entry("IPv4 single address prefix", "1.2.3.4/32", "1.2.3.4"),
+		Entry("IPv6 address in long form", "2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1"),
+		Entry("IPv6 prefix in upper case", "2001:DB8::/48", "2001:db8::/48"),
+		Entry("IPv6 single address prefix", "2001:db8::1/128", "2001:db8::1"),
+		Entry("IPv4-mapped IPv6 address", "::ffff:1.2.3.4", "1.2.3.4"),
+		Entry("surrounding whitespace", " 1.2.3.4 ", "1.2.3.4"),
+	)
+
+	It("removes duplicate entries", func() {
+		Expect(provider.ParseIPWhitelist("1.2.3.4,10.0.0.0/8,1.2.3.4/32,10.9.9.9/8")).
+			To(Equal([]string{"1.2.3.4", "10.0.0.0/8"}))
+	})
+
+	It("ignores empty entries", func() {
+		Expect(provider.ParseIPWhitelist(",1.2.3.4,,")).
+			To(Equal([]string{"1.2.3.4"}))
+	})
+
+	DescribeTable("names the entry which is invalid",
+		func(entry, expectedError string) {
+			_, err := provider.ParseIPWhitelist("8.8.8.8," + entry)
+			Expect(err).To(Equal(provider.ErrInvalidParameters{Message: expectedError}))
+		},
+		Entry("prefix length too long", "10.0.0.0/33",
+			"Invalid IP filter entry '10.0.0.0/33': not a valid CIDR prefix"),
+		Entry("octet out of range", "999.1.1.1",
+			"Invalid IP filter entry '999.1.1.1': not a valid IPv4 or IPv6 address"),
+		Entry("IPv6 prefix length too long", "2001:db8::/129",
+			"Invalid IP filter entry '2001:db8::/129': not a valid CIDR prefix"),
+		Entry("IPv6 address with a zone", "fe80::1%eth0",
+			"Invalid IP filter entry 'fe80::1%eth0': not a valid IPv4 or IPv6 address"),
+	)
+})
+
+var _ = Describe("The ParseIPFilter function", func() {
+	It("returns an error if there are too many entries", func() {
+		_, err := provider.ParseIPFilter("1.1.1.1,2.2.2.2,3.3.3.3", 2)
+		Expect(err).To(MatchError("IP filter has 3 entries but at most 2 are allowed"))
+	})
+
+	It("counts entries after removing duplicates", func() {
+		Expect(provider.ParseIPFilter("1.1.1.1,2.2.2.2,1.1.1.1/32", 2)).
+			To(Equal([]string{"1.1.1.1", "2.2.2.2"}))
+	})
+
+	It("allows as many entries as Aiven by default", func() {
+		entries := []string{}
+		for i := 0; i <= provider.DefaultMaxIPFilterEntries; i++ {
+			entries = append(entries, fmt.Sprintf("10.%d.%d.1", i/256, i%256))
+		}
+		_, err := provider.ParseIPWhitelist(strings.Join(entries[1:], ","))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = provider.ParseIPWhitelist(strings.Join(entries, ","))
+		Expect(err).To(HaveOccurred())
+	})
+})
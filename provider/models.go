@@ -2,6 +2,13 @@ package provider
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
 
 	"github.com/pivotal-cf/brokerapi/domain"
 )
@@ -27,6 +34,17 @@ type BindData struct {
 	Details    domain.BindDetails
 }
 
+type GetBindingData struct {
+	InstanceID string
+	BindingID  string
+}
+
+type LastBindingOperationData struct {
+	InstanceID    string
+	BindingID     string
+	OperationData string
+}
+
 type UnbindData struct {
 	InstanceID string
 	BindingID  string
@@ -41,21 +59,159 @@ type UpdateData struct {
 	RawParameters json.RawMessage
 }
 
+type GetInstanceData struct {
+	InstanceID string
+}
+
 type LastOperationData struct {
 	InstanceID    string
 	OperationData string
 }
 
+type ListBackupsData struct {
+	InstanceID string
+}
+
+type Backup struct {
+	Name string    `json:"name"`
+	Time time.Time `json:"time"`
+	Size int       `json:"size"`
+}
+
 type ProvisionParameters struct {
-	UserIpFilter                  string  `json:"ip_filter"`
-	RestoreFromLatestBackupOf     *string `json:"restore_from_latest_backup_of"`
-	RestoreFromLatestBackupBefore *string `json:"restore_from_latest_backup_before"`
+	UserIpFilter                  string             `json:"ip_filter"`
+	RestoreFromLatestBackupOf     *string            `json:"restore_from_latest_backup_of"`
+	RestoreFromLatestBackupBefore *string            `json:"restore_from_latest_backup_before"`
+	RestoreFromPointInTimeOf      *string            `json:"restore_from_point_in_time_of"`
+	RestoreFromPointInTimeBefore  *string            `json:"restore_from_point_in_time_before"`
+	KafkaTopics                   []KafkaTopic       `json:"topics"`
+	MaintenanceWindow             *MaintenanceWindow `json:"maintenance_window"`
+	TerminationProtection         *bool              `json:"termination_protection"`
+}
+
+// MaintenanceWindow is the weekly window in which Aiven may upgrade the
+// service, starting at Time (UTC) on the day of the week DOW
+type MaintenanceWindow struct {
+	DOW  string `json:"dow"`
+	Time string `json:"time"`
+}
+
+type KafkaTopic struct {
+	Name        string `json:"name"`
+	Partitions  int    `json:"partitions"`
+	Replication int    `json:"replication"`
+	RetentionMs *int64 `json:"retention_ms"`
+}
+
+type BindParameters struct {
+	KafkaACLs []KafkaACL `json:"acls"`
 }
 
+type KafkaACL struct {
+	Topic      string `json:"topic"`
+	Permission string `json:"permission"`
+}
+
+type InstanceParameters struct {
+	UserIpFilter                 string             `json:"ip_filter,omitempty"`
+	RestoreFromLatestBackupOf    string             `json:"restore_from_latest_backup_of,omitempty"`
+	RestoreFromPointInTimeOf     string             `json:"restore_from_point_in_time_of,omitempty"`
+	RestoreFromPointInTimeBefore string             `json:"restore_from_point_in_time_before,omitempty"`
+	MaintenanceWindow            *MaintenanceWindow `json:"maintenance_window,omitempty"`
+	TerminationProtection        bool               `json:"termination_protection,omitempty"`
+}
+
+// UpdateParameters only change the settings which are given, so that an
+// update does not undo earlier changes. An empty ip_filter removes the
+// entries added by the user.
 type UpdateParameters struct {
-	UserIpFilter string `json:"ip_filter"`
+	UserIpFilter      *string            `json:"ip_filter"`
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window"`
+	// TerminationProtection stops the instance from being deleted until it
+	// is turned off again
+	TerminationProtection *bool `json:"termination_protection"`
 }
 
+// Aiven only accepts topic names which Kafka itself would accept
+var kafkaTopicNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+var validKafkaPermissions = []string{"admin", "read", "readwrite", "write"}
+
 func (pp *ProvisionParameters) Validate() error {
+	for _, topic := range pp.KafkaTopics {
+		if !kafkaTopicNamePattern.MatchString(topic.Name) {
+			return fmt.Errorf("Invalid topic name: '%s'", topic.Name)
+		}
+		if topic.Partitions < 0 {
+			return fmt.Errorf("Invalid number of partitions for topic '%s': %d", topic.Name, topic.Partitions)
+		}
+		if topic.Replication < 0 {
+			return fmt.Errorf("Invalid replication factor for topic '%s': %d", topic.Name, topic.Replication)
+		}
+		if topic.RetentionMs != nil && *topic.RetentionMs < -1 {
+			return fmt.Errorf("Invalid retention for topic '%s': %d", topic.Name, *topic.RetentionMs)
+		}
+	}
+	if pp.MaintenanceWindow != nil {
+		return pp.MaintenanceWindow.Validate()
+	}
+	return nil
+}
+
+func (up *UpdateParameters) Validate() error {
+	if up.MaintenanceWindow != nil {
+		return up.MaintenanceWindow.Validate()
+	}
+	return nil
+}
+
+var validMaintenanceDays = []string{
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+}
+
+// Aiven expects the start of the window with seconds, but users may leave
+// them out
+var maintenanceTimeFormats = []string{"15:04:05", "15:04"}
+
+func (mw *MaintenanceWindow) Validate() error {
+	if !contains(validMaintenanceDays, strings.ToLower(mw.DOW)) {
+		return fmt.Errorf("Invalid maintenance window day: '%s'. It must be a day of the week, such as 'sunday'", mw.DOW)
+	}
+	if _, err := parseMaintenanceTime(mw.Time); err != nil {
+		return fmt.Errorf("Invalid maintenance window time: '%s'. It must be a time in UTC, such as '03:00'", mw.Time)
+	}
+	return nil
+}
+
+// aivenMaintenance converts a validated maintenance window into the form
+// which Aiven expects
+func (mw *MaintenanceWindow) aivenMaintenance() *aiven.Maintenance {
+	start, _ := parseMaintenanceTime(mw.Time)
+	return &aiven.Maintenance{
+		DOW:  strings.ToLower(mw.DOW),
+		Time: start.Format("15:04:05"),
+	}
+}
+
+func parseMaintenanceTime(value string) (time.Time, error) {
+	var err error
+	for _, format := range maintenanceTimeFormats {
+		var start time.Time
+		if start, err = time.Parse(format, value); err == nil {
+			return start, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func (bp *BindParameters) Validate() error {
+	for _, acl := range bp.KafkaACLs {
+		if acl.Topic == "" {
+			return errors.New("Every ACL must specify a topic")
+		}
+		if !contains(validKafkaPermissions, acl.Permission) {
+			return fmt.Errorf("Invalid ACL permission: '%s'", acl.Permission)
+		}
+	}
 	return nil
 }
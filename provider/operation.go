@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+)
+
+type OperationType string
+
+const (
+	ProvisionOperation   OperationType = "provision"
+	UpdateOperation      OperationType = "update"
+	DeprovisionOperation OperationType = "deprovision"
+	// SoftDeleteOperation powers off a service instead of deleting it
+	SoftDeleteOperation OperationType = "soft_delete"
+	BindOperation       OperationType = "bind"
+)
+
+// Operation is passed to the platform as the operation data of asynchronous
+// requests, and handed back to LastOperation when it polls.
+type Operation struct {
+	Type      OperationType `json:"type"`
+	PlanID    string        `json:"plan_id,omitempty"`
+	AivenPlan string        `json:"aiven_plan,omitempty"`
+	StartedAt time.Time     `json:"started_at"`
+
+	PreviousPlanID string `json:"previous_plan_id,omitempty"`
+
+	// Aiven replaces every node of a service to change its Aiven plan, so
+	// the change has been applied once none of these nodes are left
+	PreviousNodes []string `json:"previous_nodes,omitempty"`
+
+	// Aiven returns the whole user config of a service, so only the keys
+	// the broker sent are compared
+	UserConfigKeys []string `json:"user_config_keys,omitempty"`
+	UserConfigHash string   `json:"user_config_hash,omitempty"`
+}
+
+func NewOperation(operationType OperationType, plan *Plan) Operation {
+	operation := Operation{
+		Type:      operationType,
+		StartedAt: time.Now().UTC(),
+	}
+	if plan != nil {
+		operation.PlanID = plan.ID
+		operation.AivenPlan = plan.AivenPlan
+	}
+	return operation
+}
+
+func (o Operation) WithUserConfig(userConfig aiven.UserConfig) (Operation, error) {
+	fields, err := userConfigFields(userConfig)
+	if err != nil {
+		return o, err
+	}
+	o.UserConfigKeys = make([]string, 0, len(fields))
+	for key := range fields {
+		o.UserConfigKeys = append(o.UserConfigKeys, key)
+	}
+	sort.Strings(o.UserConfigKeys)
+	o.UserConfigHash, err = hashUserConfig(fields, o.UserConfigKeys)
+	return o, err
+}
+
+func (o Operation) Encode() string {
+	b, err := json.Marshal(o)
+	if err != nil {
+		// An Operation only holds values which can always be marshalled
+		panic(err)
+	}
+	return string(b)
+}
+
+// DecodeOperation also accepts the operation data used before operations
+// were structured, so that instances which were busy during an upgrade of
+// the broker can still be polled.
+func DecodeOperation(operationData string) Operation {
+	if operationData == "deprovisioning" {
+		return Operation{Type: DeprovisionOperation}
+	}
+	operation := Operation{}
+	if err := json.Unmarshal([]byte(operationData), &operation); err != nil {
+		return Operation{}
+	}
+	return operation
+}
+
+func (o Operation) PastDeadline(deadline time.Duration) bool {
+	if o.StartedAt.IsZero() || deadline <= 0 {
+		return false
+	}
+	return time.Since(o.StartedAt) > deadline
+}
+
+// UserConfigApplied reports whether the service has the user config which
+// was requested by the operation
+func (o Operation) UserConfigApplied(userConfig aiven.UserConfig) (bool, error) {
+	if o.UserConfigHash == "" {
+		return true, nil
+	}
+	fields, err := userConfigFields(userConfig)
+	if err != nil {
+		return false, err
+	}
+	hash, err := hashUserConfig(fields, o.UserConfigKeys)
+	if err != nil {
+		return false, err
+	}
+	return hash == o.UserConfigHash, nil
+}
+
+func userConfigFields(userConfig aiven.UserConfig) (map[string]interface{}, error) {
+	b, err := json.Marshal(userConfig)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if ipFilter, ok := fields["ip_filter"].([]interface{}); ok {
+		fields["ip_filter"] = canonicalIPFilter(ipFilter)
+	}
+	return fields, nil
+}
+
+// Aiven adds the prefix length to single addresses in the IP filter. Only the
+// networks are compared, as the entries may also have descriptions.
+func canonicalIPFilter(ipFilter []interface{}) []string {
+	canonical := make([]string, 0, len(ipFilter))
+	for _, entry := range ipFilter {
+		network, ok := entry.(string)
+		if !ok {
+			object, _ := entry.(map[string]interface{})
+			network, _ = object["network"].(string)
+		}
+		network = strings.TrimSuffix(network, "/32")
+		network = strings.TrimSuffix(network, "/128")
+		canonical = append(canonical, network)
+	}
+	sort.Strings(canonical)
+	return canonical
+}
+
+func hashUserConfig(fields map[string]interface{}, keys []string) (string, error) {
+	selected := map[string]interface{}{}
+	for _, key := range keys {
+		selected[key] = fields[key]
+	}
+	// Maps are marshalled with their keys sorted, so the hash is stable
+	b, err := json.Marshal(selected)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
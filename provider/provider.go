@@ -3,6 +3,8 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,8 +15,6 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/alphagov/paas-aiven-broker/client/influxdb"
-	"github.com/alphagov/paas-aiven-broker/client/opensearch"
 	"github.com/alphagov/paas-aiven-broker/provider/aiven"
 	"github.com/pivotal-cf/brokerapi"
 	"github.com/pivotal-cf/brokerapi/domain"
@@ -40,6 +40,12 @@ func New(configJSON []byte, logger lager.Logger) (*AivenProvider, error) {
 		return nil, err
 	}
 	client := aiven.NewHttpClient(AIVEN_BASE_URL, config.APIToken, config.Project, logger)
+	if config.AivenRequestTimeoutSeconds > 0 {
+		client.HTTPClient.Timeout = time.Duration(config.AivenRequestTimeoutSeconds) * time.Second
+	}
+	if config.AivenMaxAttempts > 0 {
+		client.RetryPolicy.MaxAttempts = config.AivenMaxAttempts
+	}
 	return &AivenProvider{
 		Client:                       client,
 		Config:                       config,
@@ -96,42 +102,87 @@ func (ap *AivenProvider) Provision(
 	if ap.AllowUserProvisionParameters && len(provisionData.Details.RawParameters) > 0 {
 		decoder := json.NewDecoder(bytes.NewReader(provisionData.Details.RawParameters))
 		if err := decoder.Decode(&provisionParameters); err != nil {
-			return domain.ProvisionedServiceSpec{}, err
+			return domain.ProvisionedServiceSpec{}, ErrInvalidParameters{Message: err.Error()}
 		}
 		if err := provisionParameters.Validate(); err != nil {
-			return domain.ProvisionedServiceSpec{}, err
+			return domain.ProvisionedServiceSpec{}, ErrInvalidParameters{Message: err.Error()}
 		}
 	}
 
 	userConfig := aiven.UserConfig{}
 
-	addressList := IPAddresses(provisionParameters.UserIpFilter)
-	filterlist, err := ParseIPWhitelist(addressList)
+	filterlist, err := ap.buildIPFilter(plan, provisionParameters.UserIpFilter, true)
 	if err != nil {
-		return domain.ProvisionedServiceSpec{}, err
+		return domain.ProvisionedServiceSpec{}, ErrInvalidParameters{Message: err.Error()}
 	}
-	userConfig.IPFilter = filterlist
+	userConfig.IPFilter = aiven.NewIPFilter(filterlist...)
 
-	if provisionData.Service.Name == "opensearch" {
-		userConfig.OpenSearchVersion = plan.OpenSearchVersion
-	} else if provisionData.Service.Name == "influxdb" {
-		// Nothing to do
-	} else {
+	driver, err := FindServiceTypeDriver(provisionData.Service.Name)
+	if err != nil {
 		return domain.ProvisionedServiceSpec{}, fmt.Errorf(
 			"Cannot provision service for unknown service %s",
 			provisionData.Service.Name,
 		)
 	}
+	driver.BuildUserConfig(*plan, &userConfig)
+
+	if len(provisionParameters.KafkaTopics) > 0 {
+		if !driver.SupportsTopics() {
+			return domain.ProvisionedServiceSpec{}, invalidParameters(
+				"Parameter topics is not supported for %s services", provisionData.Service.Name,
+			)
+		}
+		if provisionParameters.RestoreFromLatestBackupOf != nil || provisionParameters.RestoreFromPointInTimeOf != nil {
+			return domain.ProvisionedServiceSpec{}, invalidParameters(
+				"Parameter topics cannot be used when restoring a service",
+			)
+		}
+	}
 
 	if provisionParameters.RestoreFromLatestBackupOf == nil && provisionParameters.RestoreFromLatestBackupBefore != nil {
-		return domain.ProvisionedServiceSpec{}, fmt.Errorf(
+		return domain.ProvisionedServiceSpec{}, invalidParameters(
 			"Parameter restore_from_latest_backup_before should be used with restore_from_latest_backup_of",
 		)
 	}
 
+	if provisionParameters.RestoreFromPointInTimeOf == nil && provisionParameters.RestoreFromPointInTimeBefore != nil {
+		return domain.ProvisionedServiceSpec{}, invalidParameters(
+			"Parameter restore_from_point_in_time_before should be used with restore_from_point_in_time_of",
+		)
+	}
+
+	if provisionParameters.RestoreFromLatestBackupOf != nil && provisionParameters.RestoreFromPointInTimeOf != nil {
+		return domain.ProvisionedServiceSpec{}, invalidParameters(
+			"Parameters restore_from_latest_backup_of and restore_from_point_in_time_of cannot be used together",
+		)
+	}
+
+	existingSpec, err := ap.existingInstance(ctx, provisionData, plan)
+	if err != nil {
+		return domain.ProvisionedServiceSpec{}, err
+	}
+	if existingSpec != nil {
+		// Creating the topics may have failed after the service was created,
+		// so a retry creates any topics which are still missing
+		err := ap.createKafkaTopics(ctx, ap.BuildServiceName(provisionData.InstanceID), provisionParameters.KafkaTopics)
+		if err != nil {
+			return domain.ProvisionedServiceSpec{}, err
+		}
+		return *existingSpec, nil
+	}
+
 	if provisionParameters.RestoreFromLatestBackupOf != nil {
 		err := ap.forkFromBackup(
-			ctx, provisionData, asyncAllowed,
+			ctx, provisionData, plan, asyncAllowed,
+			provisionParameters, userConfig, tags,
+		)
+		if err != nil {
+			return domain.ProvisionedServiceSpec{}, err
+		}
+
+	} else if provisionParameters.RestoreFromPointInTimeOf != nil {
+		err := ap.restoreFromPointInTime(
+			ctx, provisionData, plan, asyncAllowed,
 			provisionParameters, userConfig, tags,
 		)
 		if err != nil {
@@ -146,62 +197,259 @@ func (ap *AivenProvider) Provision(
 			ServiceType: provisionData.Service.Name,
 			UserConfig:  userConfig,
 			Tags:        tags,
+			Maintenance: maintenanceFor(plan, provisionParameters.MaintenanceWindow),
+
+			TerminationProtection: terminationProtectionFor(plan, provisionParameters.TerminationProtection),
 		}
 		if err != nil {
 			return domain.ProvisionedServiceSpec{}, err
 		}
 
-		if _, err := ap.Client.CreateService(createServiceInput); err != nil {
+		if _, err := ap.Client.CreateService(ctx, createServiceInput); err != nil {
+			if aiven.IsConflict(err) {
+				return domain.ProvisionedServiceSpec{}, apiresponses.ErrInstanceAlreadyExists
+			}
+			return domain.ProvisionedServiceSpec{}, AivenFailureResponse(err)
+		}
+
+		err := ap.createKafkaTopics(ctx, createServiceInput.ServiceName, provisionParameters.KafkaTopics)
+		if err != nil {
 			return domain.ProvisionedServiceSpec{}, err
 		}
 	}
-	return domain.ProvisionedServiceSpec{IsAsync: true}, nil
+	return domain.ProvisionedServiceSpec{
+		IsAsync:       true,
+		OperationData: NewOperation(ProvisionOperation, plan).Encode(),
+	}, nil
+}
+
+// createKafkaTopics creates the topics which do not exist yet. Aiven accepts
+// topics while the service is still being built and creates them once it is
+// running.
+func (ap *AivenProvider) createKafkaTopics(ctx context.Context, serviceName string, topics []KafkaTopic) error {
+	for _, topic := range topics {
+		err := ap.Client.CreateKafkaTopic(ctx, &aiven.CreateKafkaTopicInput{
+			ServiceName: serviceName,
+			TopicName:   topic.Name,
+			Partitions:  topic.Partitions,
+			Replication: topic.Replication,
+			Config: aiven.KafkaTopicConfig{
+				RetentionMs: topic.RetentionMs,
+			},
+		})
+		if err != nil && !aiven.IsConflict(err) {
+			return AivenFailureResponse(err)
+		}
+	}
+	return nil
+}
+
+// The platform may retry a provision request, in which case the service has
+// already been created. Identical requests are reported as succeeding (or
+// still in progress) while requests for a different plan, org or space
+// conflict with the existing instance.
+func (ap *AivenProvider) existingInstance(
+	ctx context.Context,
+	provisionData ProvisionData,
+	plan *Plan,
+) (*domain.ProvisionedServiceSpec, error) {
+	serviceName := ap.BuildServiceName(provisionData.InstanceID)
+
+	service, err := ap.Client.GetService(ctx, &aiven.GetServiceInput{
+		ServiceName: serviceName,
+	})
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	tags, err := ap.Client.GetServiceTags(ctx, &aiven.GetServiceTagsInput{
+		ServiceName: serviceName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if tags.PlanID != provisionData.Plan.ID ||
+		tags.OrganizationID != provisionData.Details.OrganizationGUID ||
+		tags.SpaceID != provisionData.Details.SpaceGUID {
+		ap.Logger.Info("conflicting-instance-exists", lager.Data{
+			"instance-id":       provisionData.InstanceID,
+			"existing-plan-id":  tags.PlanID,
+			"existing-org-id":   tags.OrganizationID,
+			"existing-space-id": tags.SpaceID,
+		})
+		return nil, apiresponses.ErrInstanceAlreadyExists
+	}
+
+	ap.Logger.Info("instance-already-exists", lager.Data{
+		"instance-id": provisionData.InstanceID,
+		"state":       service.State,
+	})
+	if service.State == aiven.Running {
+		return &domain.ProvisionedServiceSpec{AlreadyExists: true}, nil
+	}
+	return &domain.ProvisionedServiceSpec{
+		IsAsync:       true,
+		OperationData: NewOperation(ProvisionOperation, plan).Encode(),
+	}, nil
 }
 
+var ErrTerminationProtectionEnabled = apiresponses.NewFailureResponseBuilder(
+	errors.New("Termination protection is enabled for this instance. Update the instance with the parameter termination_protection set to false before deleting it."),
+	http.StatusUnprocessableEntity, "termination-protection-enabled",
+).WithErrorKey("TerminationProtectionEnabled").Build()
+
 func (ap *AivenProvider) Deprovision(ctx context.Context, deprovisionData DeprovisionData) (operationData string, err error) {
-	err = ap.Client.DeleteService(&aiven.DeleteServiceInput{
-		ServiceName: ap.BuildServiceName(deprovisionData.InstanceID),
+	serviceName := ap.BuildServiceName(deprovisionData.InstanceID)
+
+	service, err := ap.getService(ctx, serviceName, apiresponses.ErrInstanceDoesNotExist)
+	if err != nil {
+		return "", AivenFailureResponse(err)
+	}
+	if service.TerminationProtection {
+		return "", ErrTerminationProtectionEnabled
+	}
+
+	if gracePeriod := ap.Config.SoftDeleteGracePeriod(); gracePeriod > 0 {
+		return ap.softDelete(ctx, serviceName, gracePeriod)
+	}
+
+	err = ap.Client.DeleteService(ctx, &aiven.DeleteServiceInput{
+		ServiceName: serviceName,
 	})
 
 	if err != nil {
-		if err == aiven.ErrInstanceDoesNotExist {
+		if aiven.IsNotFound(err) {
 			return "", apiresponses.ErrInstanceDoesNotExist
 		}
+		return "", AivenFailureResponse(err)
 	}
 
-	return "deprovisioning", err
+	return NewOperation(DeprovisionOperation, nil).Encode(), nil
 }
 
-func (ap *AivenProvider) Bind(ctx context.Context, bindData BindData) (binding domain.Binding, err error) {
-	serviceName := ap.BuildServiceName(bindData.InstanceID)
-	user := bindData.BindingID
+// softDelete powers off a service and tags it with the time after which it
+// can be deleted, so that it can still be recovered during the grace period.
+// The service is tagged first so that it is never left powered off without
+// being due for deletion.
+func (ap *AivenProvider) softDelete(
+	ctx context.Context,
+	serviceName string,
+	gracePeriod time.Duration,
+) (operationData string, err error) {
+	tags, err := ap.Client.GetServiceTags(ctx, &aiven.GetServiceTagsInput{
+		ServiceName: serviceName,
+	})
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return "", apiresponses.ErrInstanceDoesNotExist
+		}
+		return "", AivenFailureResponse(err)
+	}
 
-	password, err := ap.Client.CreateServiceUser(&aiven.CreateServiceUserInput{
+	deleteAfter := time.Now().UTC().Add(gracePeriod)
+	tags.DeleteAfter = &deleteAfter
+	_, err = ap.Client.UpdateServiceTags(ctx, &aiven.UpdateServiceTagsInput{
 		ServiceName: serviceName,
-		Username:    user,
+		Tags:        *tags,
 	})
 	if err != nil {
-		return domain.Binding{}, err
+		return "", fmt.Errorf("Error updating tags for service %s", serviceName)
 	}
 
-	service, err := ap.Client.GetService(&aiven.GetServiceInput{
+	powered := false
+	_, err = ap.Client.UpdateService(ctx, &aiven.UpdateServiceInput{
 		ServiceName: serviceName,
+		Powered:     &powered,
 	})
+	if err != nil {
+		return "", AivenFailureResponse(err)
+	}
+
+	return NewOperation(SoftDeleteOperation, nil).Encode(), nil
+}
+
+// A new binding fails if its credentials do not work after this long. This is
+// how long bindings waited before they were asynchronous.
+const bindingAvailabilityTimeout = 30 * time.Second
+
+func (ap *AivenProvider) Bind(
+	ctx context.Context,
+	bindData BindData,
+	asyncAllowed bool,
+) (binding domain.Binding, err error) {
+	serviceName := ap.BuildServiceName(bindData.InstanceID)
+
+	service, err := ap.Config.FindService(bindData.Details.ServiceID)
 	if err != nil {
 		return domain.Binding{}, err
 	}
 
-	host := service.ServiceUriParams.Host
-	port := service.ServiceUriParams.Port
-	serviceType := service.ServiceType
+	bindParameters := BindParameters{}
+	if len(bindData.Details.RawParameters) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(bindData.Details.RawParameters))
+		if err := decoder.Decode(&bindParameters); err != nil {
+			return domain.Binding{}, ErrInvalidParameters{Message: err.Error()}
+		}
+		if err := bindParameters.Validate(); err != nil {
+			return domain.Binding{}, ErrInvalidParameters{Message: err.Error()}
+		}
+	}
 
-	if host == "" || port == "" {
-		return domain.Binding{}, errors.New(
-			"Error getting service connection details: no connection details found in response JSON",
-		)
+	driver, err := FindServiceTypeDriver(service.Name)
+	if err != nil {
+		return domain.Binding{}, err
+	}
+
+	if len(bindParameters.KafkaACLs) > 0 && !driver.SupportsACLs() {
+		return domain.Binding{}, invalidParameters("Parameter acls is not supported for %s services", service.Name)
+	}
+
+	existingUser, err := ap.Client.GetServiceUser(ctx, &aiven.GetServiceUserInput{
+		ServiceName: serviceName,
+		Username:    bindData.BindingID,
+	})
+	if err == nil {
+		return ap.existingBinding(ctx, serviceName, driver, existingUser, bindParameters)
+	}
+	if err != aiven.ErrInstanceUserDoesNotExist {
+		return domain.Binding{}, AivenFailureResponse(err)
+	}
+
+	user, err := ap.Client.CreateServiceUser(ctx, &aiven.CreateServiceUserInput{
+		ServiceName: serviceName,
+		Username:    bindData.BindingID,
+	})
+	if err != nil {
+		if aiven.IsConflict(err) {
+			return domain.Binding{}, apiresponses.ErrBindingAlreadyExists
+		}
+		return domain.Binding{}, AivenFailureResponse(err)
+	}
+
+	if driver.SupportsACLs() {
+		if err := ap.createKafkaACLs(ctx, serviceName, user.Username, bindParameters.KafkaACLs); err != nil {
+			// A user with only some of its ACLs would make every retry
+			// conflict, so the binding is removed for the retry to start over
+			ap.removeServiceUser(ctx, serviceName, user.Username)
+			return domain.Binding{}, err
+		}
+	}
+
+	// When the platform supports asynchronous bindings we let it poll
+	// LastBindingOperation until the new user works, and fetch the
+	// credentials through GetBinding, instead of holding the request open.
+	if asyncAllowed {
+		return domain.Binding{
+			IsAsync:       true,
+			OperationData: NewOperation(BindOperation, nil).Encode(),
+		}, nil
 	}
 
-	credentials, err := BuildCredentials(serviceType, user, password, host, port)
+	serviceType, credentials, err := ap.buildServiceCredentials(ctx, serviceName, user)
 	if err != nil {
 		return domain.Binding{}, err
 	}
@@ -219,28 +467,303 @@ func (ap *AivenProvider) Bind(ctx context.Context, bindData BindData) (binding d
 	}, nil
 }
 
-func ensureUserAvailability(
+// Without any ACLs in the bind parameters, users may read and write every
+// topic of the service
+var defaultKafkaACLs = []KafkaACL{{Topic: "*", Permission: "readwrite"}}
+
+// The platform may retry a bind request, in which case the service user has
+// already been created. The credentials are returned again as long as the
+// user was created with the same parameters.
+func (ap *AivenProvider) existingBinding(
 	ctx context.Context,
-	serviceType string,
-	credentials Credentials,
-) error {
-	if serviceType == "opensearch" {
-		return tryAvailability(ctx, func() error {
-			client := opensearch.New(credentials.URI, nil)
-			_, err := client.Version()
+	serviceName string,
+	driver ServiceTypeDriver,
+	user *aiven.User,
+	bindParameters BindParameters,
+) (domain.Binding, error) {
+	if driver.SupportsACLs() {
+		identical, err := ap.hasKafkaACLs(ctx, serviceName, user.Username, bindParameters.KafkaACLs)
+		if err != nil {
+			return domain.Binding{}, err
+		}
+		if !identical {
+			return domain.Binding{}, apiresponses.ErrBindingAlreadyExists
+		}
+	}
+
+	_, credentials, err := ap.buildServiceCredentials(ctx, serviceName, user)
+	if err != nil {
+		return domain.Binding{}, err
+	}
+
+	return domain.Binding{
+		AlreadyExists: true,
+		Credentials:   credentials,
+	}, nil
+}
+
+func (ap *AivenProvider) hasKafkaACLs(ctx context.Context, serviceName, username string, acls []KafkaACL) (bool, error) {
+	if len(acls) == 0 {
+		acls = defaultKafkaACLs
+	}
+	existingACLs, err := ap.Client.ListKafkaACLs(ctx, &aiven.ListKafkaACLsInput{
+		ServiceName: serviceName,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	wanted := map[KafkaACL]bool{}
+	for _, acl := range acls {
+		wanted[acl] = true
+	}
+	existing := map[KafkaACL]bool{}
+	for _, acl := range existingACLs {
+		if acl.Username == username {
+			existing[KafkaACL{Topic: acl.Topic, Permission: acl.Permission}] = true
+		}
+	}
+
+	if len(wanted) != len(existing) {
+		return false, nil
+	}
+	for acl := range wanted {
+		if !existing[acl] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (ap *AivenProvider) createKafkaACLs(ctx context.Context, serviceName, username string, acls []KafkaACL) error {
+	if len(acls) == 0 {
+		acls = defaultKafkaACLs
+	}
+	for _, acl := range acls {
+		_, err := ap.Client.CreateKafkaACL(ctx, &aiven.CreateKafkaACLInput{
+			ServiceName: serviceName,
+			Permission:  acl.Permission,
+			Topic:       acl.Topic,
+			Username:    username,
+		})
+		if err != nil {
 			return err
+		}
+	}
+	return nil
+}
+
+// removeServiceUser cleans up after a bind which failed part way through.
+// Errors are only logged so that the original error is returned.
+func (ap *AivenProvider) removeServiceUser(ctx context.Context, serviceName, username string) {
+	if err := ap.deleteKafkaACLs(ctx, serviceName, username); err != nil {
+		ap.Logger.Error("remove-kafka-acls", err, lager.Data{
+			"service-name": serviceName,
+			"username":     username,
+		})
+	}
+	_, err := ap.Client.DeleteServiceUser(ctx, &aiven.DeleteServiceUserInput{
+		ServiceName: serviceName,
+		Username:    username,
+	})
+	if err != nil {
+		ap.Logger.Error("remove-service-user", err, lager.Data{
+			"service-name": serviceName,
+			"username":     username,
+		})
+	}
+}
+
+func (ap *AivenProvider) deleteKafkaACLs(ctx context.Context, serviceName, username string) error {
+	acls, err := ap.Client.ListKafkaACLs(ctx, &aiven.ListKafkaACLsInput{
+		ServiceName: serviceName,
+	})
+	if err != nil {
+		return err
+	}
+	for _, acl := range acls {
+		if acl.Username != username {
+			continue
+		}
+		err := ap.Client.DeleteKafkaACL(ctx, &aiven.DeleteKafkaACLInput{
+			ServiceName: serviceName,
+			ACLID:       acl.ID,
 		})
-	} else if serviceType == "influxdb" {
-		return tryAvailability(ctx, func() error {
-			client := influxdb.New(credentials.URI, nil)
-			_, err := client.Ping()
+		if err != nil {
 			return err
+		}
+	}
+	return nil
+}
+
+func (ap *AivenProvider) GetBinding(ctx context.Context, getBindingData GetBindingData) (spec domain.GetBindingSpec, err error) {
+	serviceName := ap.BuildServiceName(getBindingData.InstanceID)
+
+	user, err := ap.Client.GetServiceUser(ctx, &aiven.GetServiceUserInput{
+		ServiceName: serviceName,
+		Username:    getBindingData.BindingID,
+	})
+	if err != nil {
+		if err == aiven.ErrInstanceUserDoesNotExist {
+			return spec, apiresponses.ErrBindingNotFound
+		}
+		return spec, err
+	}
+
+	_, credentials, err := ap.buildServiceCredentials(ctx, serviceName, user)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return spec, apiresponses.ErrBindingNotFound
+		}
+		return spec, err
+	}
+
+	return domain.GetBindingSpec{
+		Credentials: credentials,
+	}, nil
+}
+
+func (ap *AivenProvider) buildServiceCredentials(
+	ctx context.Context,
+	serviceName string,
+	user *aiven.User,
+) (serviceType string, credentials Credentials, err error) {
+	service, err := ap.Client.GetService(ctx, &aiven.GetServiceInput{
+		ServiceName: serviceName,
+	})
+	if err != nil {
+		return "", Credentials{}, err
+	}
+
+	host := service.ServiceUriParams.Host
+	port := service.ServiceUriParams.Port
+	serviceType = service.ServiceType
+
+	if host == "" || port == "" {
+		return "", Credentials{}, errors.New(
+			"Error getting service connection details: no connection details found in response JSON",
+		)
+	}
+
+	driver, err := FindServiceTypeDriver(serviceType)
+	if err != nil {
+		return "", Credentials{}, err
+	}
+
+	credentials = buildCredentials(driver, user, host, port)
+
+	if driver.RequiresProjectCA() {
+		credentials.CACertificate, err = ap.Client.GetProjectCA(ctx)
+		if err != nil {
+			return "", Credentials{}, err
+		}
+	}
+	return serviceType, credentials, nil
+}
+
+func (ap *AivenProvider) LastBindingOperation(
+	ctx context.Context,
+	lastBindingOperationData LastBindingOperationData,
+) (state domain.LastOperationState, description string, err error) {
+	serviceName := ap.BuildServiceName(lastBindingOperationData.InstanceID)
+	operation := DecodeOperation(lastBindingOperationData.OperationData)
+
+	user, err := ap.Client.GetServiceUser(ctx, &aiven.GetServiceUserInput{
+		ServiceName: serviceName,
+		Username:    lastBindingOperationData.BindingID,
+	})
+	if err != nil {
+		if err == aiven.ErrInstanceUserDoesNotExist {
+			return domain.Failed, "Binding failed: service user does not exist", nil
+		}
+		return "", "", err
+	}
+
+	serviceType, credentials, err := ap.buildServiceCredentials(ctx, serviceName, user)
+	if err != nil {
+		return "", "", err
+	}
+
+	availabilityCheck, err := userAvailabilityCheck(serviceType, credentials)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := availabilityCheck(); err != nil {
+		// Aiven can take a while before new credentials work. Credentials
+		// which still do not work after the timeout are not handed out, as
+		// the application would not be able to use them either.
+		if operation.PastDeadline(bindingAvailabilityTimeout) {
+			ap.Logger.Error("binding-availability-timed-out", err, lager.Data{
+				"instance-id": lastBindingOperationData.InstanceID,
+				"binding-id":  lastBindingOperationData.BindingID,
+			})
+			return domain.Failed, fmt.Sprintf(
+				"Binding failed: the broker could not connect with the new credentials within %s. "+
+					"Check that the IP filter of the service allows the broker to connect, then try binding again.",
+				bindingAvailabilityTimeout,
+			), nil
+		}
+		ap.Logger.Debug("binding-not-yet-available", lager.Data{
+			"instance-id": lastBindingOperationData.InstanceID,
+			"binding-id":  lastBindingOperationData.BindingID,
+			"error":       err.Error(),
 		})
-	} else {
-		return fmt.Errorf(
+		return domain.InProgress, "Waiting for the credentials to become available", nil
+	}
+	return domain.Succeeded, "Binding succeeded", nil
+}
+
+func ensureUserAvailability(
+	ctx context.Context,
+	serviceType string,
+	credentials Credentials,
+) error {
+	availabilityCheck, err := userAvailabilityCheck(serviceType, credentials)
+	if err != nil {
+		return err
+	}
+	return tryAvailability(ctx, availabilityCheck)
+}
+
+func userAvailabilityCheck(
+	serviceType string,
+	credentials Credentials,
+) (func() error, error) {
+	driver, err := FindServiceTypeDriver(serviceType)
+	if err != nil {
+		return nil, fmt.Errorf(
 			"Cannot ensure availability for unknown service %s", serviceType,
 		)
 	}
+	return driver.AvailabilityCheck(credentials)
+}
+
+func caTLSConfig(caCertificate string) (*tls.Config, error) {
+	if caCertificate == "" {
+		return nil, nil
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM([]byte(caCertificate)) {
+		return nil, errors.New("Error parsing CA certificate")
+	}
+	return &tls.Config{RootCAs: rootCAs}, nil
+}
+
+func clientCertificateTLSConfig(credentials Credentials) (*tls.Config, error) {
+	tlsConfig, err := caTLSConfig(credentials.CACertificate)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	certificate, err := tls.X509KeyPair([]byte(credentials.ClientCertificate), []byte(credentials.ClientKey))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing client certificate: %s", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{certificate}
+	return tlsConfig, nil
 }
 
 func tryAvailability(
@@ -266,8 +789,27 @@ func tryAvailability(
 }
 
 func (ap *AivenProvider) Unbind(ctx context.Context, unbindData UnbindData) (err error) {
-	_, err = ap.Client.DeleteServiceUser(&aiven.DeleteServiceUserInput{
-		ServiceName: ap.BuildServiceName(unbindData.InstanceID),
+	serviceName := ap.BuildServiceName(unbindData.InstanceID)
+
+	service, err := ap.Config.FindService(unbindData.Details.ServiceID)
+	if err != nil {
+		return err
+	}
+	driver, err := FindServiceTypeDriver(service.Name)
+	if err != nil {
+		return err
+	}
+
+	// ACLs are not removed with the user, so remove them first in case a
+	// user with the same name is created again
+	if driver.SupportsACLs() {
+		if err := ap.deleteKafkaACLs(ctx, serviceName, unbindData.BindingID); err != nil {
+			return err
+		}
+	}
+
+	_, err = ap.Client.DeleteServiceUser(ctx, &aiven.DeleteServiceUserInput{
+		ServiceName: serviceName,
 		Username:    unbindData.BindingID,
 	})
 	if err == aiven.ErrInstanceUserDoesNotExist {
@@ -282,7 +824,6 @@ func (ap *AivenProvider) Update(
 	asyncAllowed bool,
 ) (result domain.UpdateServiceSpec, err error) {
 	plan, err := ap.Config.FindPlan(updateData.Details.ServiceID, updateData.Details.PlanID)
-	result.OperationData = "fail"
 	if err != nil {
 		return result, err
 	}
@@ -291,40 +832,91 @@ func (ap *AivenProvider) Update(
 	if ap.AllowUserProvisionParameters && len(updateData.Details.RawParameters) > 0 {
 		decoder := json.NewDecoder(bytes.NewReader(updateData.Details.RawParameters))
 		if err := decoder.Decode(&UpdateParameters); err != nil {
-			return result, err
+			return result, ErrInvalidParameters{Message: err.Error()}
+		}
+		if err := UpdateParameters.Validate(); err != nil {
+			return result, ErrInvalidParameters{Message: err.Error()}
 		}
 	}
 
-	userConfig := aiven.UserConfig{}
+	currentService, err := ap.getService(
+		ctx, ap.BuildServiceName(updateData.InstanceID), apiresponses.ErrInstanceDoesNotExist,
+	)
+	if err != nil {
+		return result, err
+	}
+	userConfig := updatableUserConfig(currentService.UserConfig)
+
+	// The current filter includes the ranges the broker added for the
+	// previous plan, which may not apply to the new one
+	previousPlan, _ := ap.Config.FindPlan(updateData.Details.ServiceID, updateData.Details.PreviousValues.PlanID)
+	currentUserEntries, err := ap.userIPFilterEntries(currentService.UserConfig.IPFilter, plan, previousPlan)
+	if err != nil {
+		return result, err
+	}
+	userIPFilter := strings.Join(currentUserEntries, ",")
+	if UpdateParameters.UserIpFilter != nil {
+		userIPFilter = *UpdateParameters.UserIpFilter
+	}
+	// Only the entries sent with this request are checked against the
+	// policy. The current entries may predate the policy, or be Aiven's
+	// default, and must not stop the service from changing plan.
+	filterlist, err := ap.buildIPFilter(plan, userIPFilter, UpdateParameters.UserIpFilter != nil)
+	if err != nil {
+		return result, ErrInvalidParameters{Message: err.Error()}
+	}
+	if len(filterlist) == 0 {
+		// An empty IP filter would be left out of the request, which would
+		// leave the previous entries in place
+		filterlist = []string{AivenDefaultIPFilter}
+	}
+	userConfig.IPFilter = withDescriptions(filterlist, currentService.UserConfig.IPFilter)
 
-	addressList := IPAddresses(UpdateParameters.UserIpFilter)
-	filterlist, err := ParseIPWhitelist(addressList)
+	service, err := ap.Config.FindService(updateData.Details.ServiceID)
+	if err != nil {
+		return result, err
+	}
+	driver, err := FindServiceTypeDriver(service.Name)
 	if err != nil {
 		return result, err
 	}
-	userConfig.IPFilter = filterlist
+	driver.BuildUserConfig(*plan, &userConfig)
 
-	userConfig.OpenSearchVersion = plan.OpenSearchVersion // Pass empty version through if not InfluxDB
+	operation := NewOperation(UpdateOperation, plan)
+	operation.PreviousPlanID = updateData.Details.PreviousValues.PlanID
+	if currentService.Plan != plan.AivenPlan {
+		for _, node := range currentService.NodeStates {
+			operation.PreviousNodes = append(operation.PreviousNodes, node.Name)
+		}
+	}
+	operation, err = operation.WithUserConfig(userConfig)
+	if err != nil {
+		return result, err
+	}
 
-	_, err = ap.Client.UpdateService(&aiven.UpdateServiceInput{
+	updateServiceInput := &aiven.UpdateServiceInput{
 		ServiceName: ap.BuildServiceName(updateData.InstanceID),
 		Plan:        plan.AivenPlan,
 		UserConfig:  userConfig,
-	})
+	}
+	if UpdateParameters.MaintenanceWindow != nil {
+		updateServiceInput.Maintenance = UpdateParameters.MaintenanceWindow.aivenMaintenance()
+	}
+	updateServiceInput.TerminationProtection = UpdateParameters.TerminationProtection
+	_, err = ap.Client.UpdateService(ctx, updateServiceInput)
 
 	if err != nil {
 		switch err := err.(type) {
 		case aiven.ErrInvalidUpdate:
-			return result, apiresponses.NewFailureResponseBuilder(
-				err,
-				http.StatusUnprocessableEntity,
-				"plan-change-not-supported",
-			).WithErrorKey("PlanChangeNotSupported").Build()
+			return result, newAivenFailure(err,
+				"Aiven does not support this plan change",
+				http.StatusUnprocessableEntity, "plan-change-not-supported", "PlanChangeNotSupported",
+			)
 		default:
-			return result, err
+			return result, AivenFailureResponse(err)
 		}
 	}
-	serviceTags, err := ap.Client.GetServiceTags(&aiven.GetServiceTagsInput{
+	serviceTags, err := ap.Client.GetServiceTags(ctx, &aiven.GetServiceTagsInput{
 		ServiceName: ap.BuildServiceName(updateData.InstanceID),
 	})
 	if err != nil {
@@ -333,92 +925,394 @@ func (ap *AivenProvider) Update(
 
 	serviceTags.PlanID = plan.ID
 
-	_, err = ap.Client.UpdateServiceTags(&aiven.UpdateServiceTagsInput{
+	_, err = ap.Client.UpdateServiceTags(ctx, &aiven.UpdateServiceTagsInput{
 		ServiceName: ap.BuildServiceName(updateData.InstanceID),
 		Tags:        *serviceTags,
 	})
 	if err != nil {
 		return result, fmt.Errorf("Error updating tags for service %s", ap.BuildServiceName(updateData.InstanceID))
 	}
-	result.OperationData = ""
+	result.OperationData = operation.Encode()
 	result.IsAsync = asyncAllowed
 	return
 }
 
+// maintenanceFor returns the maintenance window requested by the user, or
+// the default of the plan
+func maintenanceFor(plan *Plan, requested *MaintenanceWindow) *aiven.Maintenance {
+	if requested != nil {
+		return requested.aivenMaintenance()
+	}
+	if plan.MaintenanceWindow != nil {
+		return plan.MaintenanceWindow.aivenMaintenance()
+	}
+	return nil
+}
+
+// terminationProtectionFor returns whether the user asked for termination
+// protection, or the default of the plan
+func terminationProtectionFor(plan *Plan, requested *bool) bool {
+	if requested != nil {
+		return *requested
+	}
+	return plan.TerminationProtection
+}
+
+// updatableUserConfig starts an update from the current user config of the
+// service, so that settings which are not being changed are sent back to
+// Aiven unchanged. The settings used to fork a service can only be set when
+// it is created.
+func updatableUserConfig(current aiven.UserConfig) aiven.UserConfig {
+	userConfig := current
+	userConfig.ForkProject = ""
+	userConfig.BackupServiceName = ""
+	userConfig.BackupName = ""
+	userConfig.RecoveryTargetTime = ""
+	return userConfig
+}
+
 func (ap *AivenProvider) LastOperation(
 	ctx context.Context,
 	lastOperationData LastOperationData,
 ) (state domain.LastOperationState, description string, err error) {
 	serviceName := ap.BuildServiceName(lastOperationData.InstanceID)
+	operation := DecodeOperation(lastOperationData.OperationData)
 
-	service, err := ap.Client.GetService(&aiven.GetServiceInput{
+	service, err := ap.Client.GetService(ctx, &aiven.GetServiceInput{
 		ServiceName: serviceName,
 	})
 	if err != nil {
-		if lastOperationData.OperationData == "deprovisioning" {
-			if err == aiven.ErrInstanceDoesNotExist {
+		if aiven.IsNotFound(err) {
+			switch operation.Type {
+			case DeprovisionOperation, SoftDeleteOperation:
 				return domain.Succeeded, "Service has been deleted", nil
+			case ProvisionOperation, UpdateOperation:
+				state, description := providerStatesMapping(&aiven.Service{State: aiven.Missing})
+				return state, description, nil
 			}
 		}
 		return "", "", err
 	}
 
-	status := service.State
-	updateTime := service.UpdateTime
+	state, description = ap.operationState(operation, service)
+
+	if state == domain.InProgress && operation.PastDeadline(ap.Config.OperationDeadline()) {
+		ap.Logger.Error("operation-deadline-exceeded", errors.New(description), lager.Data{
+			"instance-id": lastOperationData.InstanceID,
+			"operation":   operation,
+		})
+		return domain.Failed, fmt.Sprintf(
+			"Last operation failed: not completed within %s (%s)",
+			ap.Config.OperationDeadline(), description,
+		), nil
+	}
+	return state, description, nil
+}
+
+func (ap *AivenProvider) operationState(
+	operation Operation,
+	service *aiven.Service,
+) (domain.LastOperationState, string) {
+	if operation.Type == DeprovisionOperation {
+		return domain.InProgress, "Deleting service"
+	}
+	if operation.Type == SoftDeleteOperation {
+		if service.State == aiven.PowerOff {
+			return domain.Succeeded, "Service has been powered off and will be deleted later"
+		}
+		return domain.InProgress, "Powering off service"
+	}
+
+	// Aiven powers off services which it cannot finish creating, for
+	// example because the project ran out of credit
+	if service.State == aiven.PowerOff && operation.Type == ProvisionOperation {
+		return domain.Failed, "Last operation failed: service was powered off by Aiven while it was being created"
+	}
+
+	state, description := providerStatesMapping(service)
+	if state != domain.Succeeded || operation.Type != UpdateOperation {
+		return state, description
+	}
+
+	if !planChangeApplied(operation, service) {
+		return domain.InProgress, "Waiting for the new plan to be applied"
+	}
+	applied, err := operation.UserConfigApplied(service.UserConfig)
+	if err != nil || !applied {
+		return domain.InProgress, "Waiting for the new configuration to be applied"
+	}
+	return state, description
+}
+
+// planChangeApplied reports whether Aiven has replaced the nodes the service
+// had before its plan was changed. Aiven reports the new plan and a state of
+// 'RUNNING' as soon as a plan change is requested, and only starts building
+// the new nodes a few seconds later, so the nodes are the only reliable sign
+// that the change has been applied.
+func planChangeApplied(operation Operation, service *aiven.Service) bool {
+	if len(operation.PreviousNodes) == 0 {
+		return true
+	}
+	if len(service.NodeStates) == 0 {
+		return false
+	}
+	for _, node := range service.NodeStates {
+		if contains(operation.PreviousNodes, node.Name) || node.State != aiven.NodeRunning {
+			return false
+		}
+	}
+	return true
+}
+
+var ErrInstanceNotFound = apiresponses.NewFailureResponseBuilder(
+	errors.New("instance does not exist"), http.StatusNotFound, "instance-not-found",
+).WithEmptyResponse().Build()
+
+func (ap *AivenProvider) GetInstance(
+	ctx context.Context,
+	getInstanceData GetInstanceData,
+) (spec domain.GetInstanceDetailsSpec, err error) {
+	serviceName := ap.BuildServiceName(getInstanceData.InstanceID)
+
+	service, err := ap.getService(ctx, serviceName, ErrInstanceNotFound)
+	if err != nil {
+		return spec, err
+	}
+
+	tags, err := ap.Client.GetServiceTags(ctx, &aiven.GetServiceTagsInput{
+		ServiceName: serviceName,
+	})
+	if err != nil {
+		return spec, err
+	}
+
+	catalogService, err := ap.Config.FindServiceByPlan(tags.PlanID)
+	if err != nil {
+		return spec, err
+	}
+	plan, err := ap.Config.FindPlan(catalogService.ID, tags.PlanID)
+	if err != nil {
+		return spec, err
+	}
+	userIPFilter, err := ap.userIPFilterEntries(service.UserConfig.IPFilter, plan)
+	if err != nil {
+		return spec, err
+	}
+
+	parameters := InstanceParameters{
+		UserIpFilter:          strings.Join(userIPFilter, ","),
+		TerminationProtection: service.TerminationProtection,
+	}
+	if tags.RestoredFromBackup == "true" {
+		if tags.RestoredFromPointInTime == "true" {
+			parameters.RestoreFromPointInTimeOf = tags.OriginServiceID
+			parameters.RestoreFromPointInTimeBefore = tags.RestoredFromTime.UTC().Format(RestoreFromPointInTimeBeforeTimeFormat)
+		} else {
+			parameters.RestoreFromLatestBackupOf = tags.OriginServiceID
+		}
+	}
+	if service.Maintenance != nil {
+		parameters.MaintenanceWindow = &MaintenanceWindow{
+			DOW:  service.Maintenance.DOW,
+			Time: service.Maintenance.Time,
+		}
+	}
+
+	return domain.GetInstanceDetailsSpec{
+		ServiceID:  catalogService.ID,
+		PlanID:     tags.PlanID,
+		Parameters: parameters,
+	}, nil
+}
 
-	if updateTime.After(time.Now().Add(-1 * 60 * time.Second)) {
-		return domain.InProgress, "Preparing to apply update", nil
+// ListBackups returns the backups of an instance, newest first, so that users
+// can choose a time for restore_from_latest_backup_before
+func (ap *AivenProvider) ListBackups(
+	ctx context.Context,
+	listBackupsData ListBackupsData,
+) ([]Backup, error) {
+	serviceBackups, err := ap.Client.ListServiceBackups(ctx, &aiven.ListServiceBackupsInput{
+		ServiceName: ap.BuildServiceName(listBackupsData.InstanceID),
+	})
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil, ErrInstanceNotFound
+		}
+		return nil, err
 	}
 
-	lastOperationState, description := providerStatesMapping(status)
-	return lastOperationState, description, nil
+	backups := make([]Backup, 0, len(serviceBackups))
+	for _, serviceBackup := range serviceBackups {
+		backups = append(backups, Backup{
+			Name: serviceBackup.Name,
+			Time: serviceBackup.Time,
+			Size: serviceBackup.Size,
+		})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
 }
 
 func (ap *AivenProvider) restoreFromPointInTime(
 	ctx context.Context,
 	provisionData ProvisionData,
+	plan *Plan,
 	asyncAllowed bool,
 	provisionParameters ProvisionParameters,
 	userConfig aiven.UserConfig, tags aiven.ServiceTags,
 ) error {
-	return fmt.Errorf("restoreFromPointInTime not implemented yet.")
+	if *provisionParameters.RestoreFromPointInTimeOf == "" {
+		return invalidParameters("Invalid guid: '%s'", *provisionParameters.RestoreFromPointInTimeOf)
+	}
+	if provisionParameters.RestoreFromPointInTimeBefore == nil || *provisionParameters.RestoreFromPointInTimeBefore == "" {
+		return invalidParameters("Parameter restore_from_point_in_time_before must be set when using restore_from_point_in_time_of")
+	}
+	driver, err := FindServiceTypeDriver(provisionData.Service.Name)
+	if err != nil || !driver.SupportsPointInTimeRestore() {
+		return invalidParameters("Point in time restore not supported for service '%s'", provisionData.Service.Name)
+	}
+
+	recoveryTargetTime, err := time.ParseInLocation(
+		RestoreFromPointInTimeBeforeTimeFormat,
+		*provisionParameters.RestoreFromPointInTimeBefore,
+		time.UTC,
+	)
+	if err != nil {
+		return invalidParameters("Parameter restore_from_point_in_time_before should be a date and a time: %s", err)
+	}
+
+	sourceServiceName, sourceService, err := ap.restoreSource(
+		ctx, provisionData, *provisionParameters.RestoreFromPointInTimeOf,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Aiven can replay the write-ahead log from the oldest backup it still
+	// keeps, so that is as far back as a service can be restored
+	if len(sourceService.Backups) == 0 {
+		return invalidParameters("No backups found for '%s'", *provisionParameters.RestoreFromPointInTimeOf)
+	}
+	backups := sourceService.Backups
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	oldestBackup := backups[len(backups)-1].Time
+	if recoveryTargetTime.Before(oldestBackup) || recoveryTargetTime.After(time.Now()) {
+		return invalidParameters(
+			"Parameter restore_from_point_in_time_before must be between %s and now",
+			oldestBackup.UTC().Format(RestoreFromPointInTimeBeforeTimeFormat),
+		)
+	}
+
+	// The write-ahead log is replayed on top of the latest backup taken
+	// before the recovery target
+	baseBackup := backups[len(backups)-1]
+	for _, backup := range backups {
+		if !backup.Time.After(recoveryTargetTime) {
+			baseBackup = backup
+			break
+		}
+	}
+	if err := checkBackupFitsPlan(baseBackup, plan); err != nil {
+		return err
+	}
+
+	ap.Logger.Info("restoring-from-point-in-time", lager.Data{
+		"instanceIDLogKey":   provisionData.InstanceID,
+		"detailsLogKey":      provisionData.Details,
+		"recoveryTargetTime": recoveryTargetTime,
+	})
+	tags.RestoredFromBackup = "true"
+	tags.OriginServiceID = *provisionParameters.RestoreFromPointInTimeOf
+	tags.RestoredFromTime = recoveryTargetTime
+	tags.RestoredFromPointInTime = "true"
+	userConfig.ForkProject = ap.Config.Project
+	userConfig.BackupServiceName = sourceServiceName
+	userConfig.RecoveryTargetTime = recoveryTargetTime.Format(RestoreFromPointInTimeBeforeTimeFormat)
+	forkServiceInput := aiven.ForkServiceInput{
+		Cloud:       ap.Config.Cloud,
+		Plan:        plan.AivenPlan,
+		ServiceName: ap.BuildServiceName(provisionData.InstanceID),
+		ServiceType: provisionData.Service.Name,
+		UserConfig:  userConfig,
+		Tags:        tags,
+		Maintenance: maintenanceFor(plan, provisionParameters.MaintenanceWindow),
+
+		TerminationProtection: terminationProtectionFor(plan, provisionParameters.TerminationProtection),
+	}
+
+	_, err = ap.Client.ForkService(ctx, &forkServiceInput)
+	if aiven.IsConflict(err) {
+		return apiresponses.ErrInstanceAlreadyExists
+	}
+	return AivenFailureResponse(err)
+}
+
+// restoreSource looks up the service which a new instance is restored from
+// and checks that it can be restored into the new instance
+func (ap *AivenProvider) restoreSource(
+	ctx context.Context,
+	provisionData ProvisionData,
+	sourceInstanceID string,
+) (string, *aiven.Service, error) {
+	sourceServiceName := ap.BuildServiceName(sourceInstanceID)
+
+	sourceService, err := ap.getService(
+		ctx, sourceServiceName, invalidParameters("Service instance '%s' does not exist", sourceInstanceID),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+	sourceServiceTags, err := ap.Client.GetServiceTags(ctx, &aiven.GetServiceTagsInput{
+		ServiceName: sourceServiceName,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if !compatibleServiceTypes(provisionData.Service.Name, sourceService.ServiceType) {
+		return "", nil, invalidParameters("You cannot restore an %s backup to %s", sourceService.ServiceType, provisionData.Service.Name)
+	}
+	if err := ap.CheckPermissionsFromTags(provisionData.Details, sourceServiceTags); err != nil {
+		return "", nil, err
+	}
+	return sourceServiceName, sourceService, nil
+}
+
+// Backups of Elasticsearch services can be restored to OpenSearch, so those
+// are compared by their suffix
+func compatibleServiceTypes(serviceType, sourceServiceType string) bool {
+	if serviceType == sourceServiceType {
+		return true
+	}
+	return strings.HasSuffix(serviceType, "search") && strings.HasSuffix(sourceServiceType, "search")
 }
 
 func (ap *AivenProvider) forkFromBackup(
 	ctx context.Context,
 	provisionData ProvisionData,
+	plan *Plan,
 	asyncAllowed bool,
 	provisionParameters ProvisionParameters,
 	userConfig aiven.UserConfig, tags aiven.ServiceTags,
 ) error {
 	if *provisionParameters.RestoreFromLatestBackupOf == "" {
-		return fmt.Errorf("Invalid guid: '%s'", *provisionParameters.RestoreFromLatestBackupOf)
+		return invalidParameters("Invalid guid: '%s'", *provisionParameters.RestoreFromLatestBackupOf)
 	}
 	if service := provisionData.Service.Name; service != "" {
-		if service != "opensearch" {
-			return fmt.Errorf("Restore from backup not supported for service '%s'", service)
+		driver, err := FindServiceTypeDriver(service)
+		if err != nil || !driver.SupportsFork() {
+			return invalidParameters("Restore from backup not supported for service '%s'", service)
 		}
 	}
-	forkFromBackupInstanceName := ap.BuildServiceName(*provisionParameters.RestoreFromLatestBackupOf)
-
-	sourceService, err := ap.Client.GetService(&aiven.GetServiceInput{
-		ServiceName: forkFromBackupInstanceName,
-	})
+	forkFromBackupInstanceName, sourceService, err := ap.restoreSource(
+		ctx, provisionData, *provisionParameters.RestoreFromLatestBackupOf,
+	)
 	if err != nil {
 		return err
 	}
-	sourceServiceTags, err := ap.Client.GetServiceTags(&aiven.GetServiceTagsInput{
-		ServiceName: forkFromBackupInstanceName,
-	})
-	if err != nil {
-		return err
-	}
-	if provisionData.Service.Name[len(provisionData.Service.Name)-6:] != sourceService.ServiceType[len(sourceService.ServiceType)-6:] {
-		return fmt.Errorf("You cannot restore an %s backup to %s", sourceService.ServiceType, provisionData.Service.Name)
-	}
-	if err := ap.CheckPermissionsFromTags(provisionData.Details, sourceServiceTags); err != nil {
-		return err
-	}
 	backups := sourceService.Backups
 	sort.SliceStable(backups, func(i, j int) bool {
 		return backups[i].Time.After(backups[j].Time)
@@ -426,7 +1320,7 @@ func (ap *AivenProvider) forkFromBackup(
 
 	if provisionParameters.RestoreFromLatestBackupBefore != nil {
 		if *provisionParameters.RestoreFromLatestBackupBefore == "" {
-			return fmt.Errorf("Parameter restore_from_latest_snapshot_before must not be empty")
+			return invalidParameters("Parameter restore_from_latest_snapshot_before must not be empty")
 		}
 
 		restoreFromLatestSnapshotBeforeTime, err := time.ParseInLocation(
@@ -435,7 +1329,7 @@ func (ap *AivenProvider) forkFromBackup(
 			time.UTC,
 		)
 		if err != nil {
-			return fmt.Errorf("Parameter restore_from_latest_snapshot_before should be a date and a time: %s", err)
+			return invalidParameters("Parameter restore_from_latest_snapshot_before should be a date and a time: %s", err)
 		}
 
 		prunedBackups := make([]aiven.ServiceBackup, 0)
@@ -456,10 +1350,13 @@ func (ap *AivenProvider) forkFromBackup(
 	}
 
 	if len(backups) == 0 {
-		return fmt.Errorf("No backups found for '%s'", *provisionParameters.RestoreFromLatestBackupOf)
+		return invalidParameters("No backups found for '%s'", *provisionParameters.RestoreFromLatestBackupOf)
 	}
 
 	backup := backups[0]
+	if err := checkBackupFitsPlan(backup, plan); err != nil {
+		return err
+	}
 
 	ap.Logger.Info("chose-snapshot", lager.Data{
 		"instanceIDLogKey":   provisionData.InstanceID,
@@ -467,64 +1364,76 @@ func (ap *AivenProvider) forkFromBackup(
 		"snapshotIdentifier": backup.Name,
 	})
 	tags.RestoredFromBackup = "true"
+	tags.OriginServiceID = *provisionParameters.RestoreFromLatestBackupOf
 	tags.RestoredFromTime = backup.Time
 	userConfig.ForkProject = ap.Config.Project
 	userConfig.BackupServiceName = forkFromBackupInstanceName
 	userConfig.BackupName = backup.Name
 	forkServiceInput := aiven.ForkServiceInput{
 		Cloud:       ap.Config.Cloud,
-		Plan:        sourceService.Plan,
+		Plan:        plan.AivenPlan,
 		ServiceName: ap.BuildServiceName(provisionData.InstanceID),
 		ServiceType: provisionData.Service.Name,
 		UserConfig:  userConfig,
 		Tags:        tags,
+		Maintenance: maintenanceFor(plan, provisionParameters.MaintenanceWindow),
+
+		TerminationProtection: terminationProtectionFor(plan, provisionParameters.TerminationProtection),
 	}
 	if err != nil {
 		return err
 	}
-	_, err = ap.Client.ForkService(&forkServiceInput)
-	return err
+	_, err = ap.Client.ForkService(ctx, &forkServiceInput)
+	if aiven.IsConflict(err) {
+		return apiresponses.ErrInstanceAlreadyExists
+	}
+	return AivenFailureResponse(err)
+}
+
+// checkBackupFitsPlan allows a backup to be restored onto any plan with enough
+// disk space for its data, so that users can move to a different plan as part
+// of a restore. Plans without a known disk size are left to Aiven to check.
+func checkBackupFitsPlan(backup aiven.ServiceBackup, plan *Plan) error {
+	if plan.DiskSpaceMB <= 0 {
+		return nil
+	}
+	backupSizeMB := (int64(backup.Size) + megabyte - 1) / megabyte
+	if backupSizeMB <= int64(plan.DiskSpaceMB) {
+		return nil
+	}
+	return apiresponses.NewFailureResponseBuilder(
+		fmt.Errorf(
+			"Backup '%s' needs %d MB of disk space but plan '%s' only has %d MB. Please choose a larger plan.",
+			backup.Name, backupSizeMB, plan.Name, plan.DiskSpaceMB,
+		),
+		http.StatusUnprocessableEntity, "backup-too-large",
+	).WithErrorKey("BackupTooLarge").Build()
 }
 
+const megabyte = 1024 * 1024
+
 func (ap *AivenProvider) BuildServiceName(guid string) string {
 	return strings.ToLower(ap.Config.ServiceNamePrefix + "-" + guid)
 }
 
+// getService looks up a service in Aiven, and returns notFound instead of
+// Aiven's error if the service does not exist
+func (ap *AivenProvider) getService(ctx context.Context, serviceName string, notFound error) (*aiven.Service, error) {
+	service, err := ap.Client.GetService(ctx, &aiven.GetServiceInput{
+		ServiceName: serviceName,
+	})
+	if aiven.IsNotFound(err) {
+		return nil, notFound
+	}
+	return service, err
+}
+
 func (ap *AivenProvider) CheckPermissionsFromTags(
 	details domain.ProvisionDetails,
 	tags *aiven.ServiceTags,
 ) error {
 	if tags.SpaceID != details.SpaceGUID || tags.OrganizationID != details.OrganizationGUID {
-		return fmt.Errorf("The service instance you are getting a backup from is not in the same org or space")
+		return invalidParameters("The service instance you are getting a backup from is not in the same org or space")
 	}
 	return nil
 }
-
-func ParseIPWhitelist(ips string) ([]string, error) {
-	if ips == "" {
-		return []string{}, nil
-	}
-	outIPs := []string{}
-	for _, ip := range strings.Split(ips, ",") {
-		if len(strings.Split(ip, ".")) != 4 {
-			return []string{}, fmt.Errorf("malformed whitelist IP: %v", ip)
-		}
-		outIPs = append(outIPs, ip)
-	}
-	return outIPs, nil
-}
-
-func providerStatesMapping(status aiven.ServiceStatus) (domain.LastOperationState, string) {
-	switch status {
-	case aiven.Running:
-		return domain.Succeeded, "Last operation succeeded"
-	case aiven.Rebuilding:
-		return domain.InProgress, "Rebuilding"
-	case aiven.Rebalancing:
-		return domain.InProgress, "Rebalancing"
-	case aiven.PowerOff:
-		return domain.Failed, "Last operation failed: service is powered off"
-	default:
-		return domain.InProgress, fmt.Sprintf("Unknown state: %s", status)
-	}
-}
@@ -12,7 +12,7 @@ var _ = Describe("Provider internals", func() {
 
 	DescribeTable("providerStatesMapping",
 		func(inputState aiven.ServiceStatus, expectedState brokerapi.LastOperationState, expectedDescription string) {
-			state, description := providerStatesMapping(inputState)
+			state, description := providerStatesMapping(&aiven.Service{State: inputState})
 			Expect(state).To(Equal(expectedState))
 			Expect(description).To(Equal(expectedDescription))
 		},
@@ -20,6 +20,51 @@ var _ = Describe("Provider internals", func() {
 		Entry("returns 'in progress' when REBUILDING", aiven.Rebuilding, brokerapi.InProgress, "Rebuilding"),
 		Entry("returns 'in progress' when REBALANCING", aiven.Rebalancing, brokerapi.InProgress, "Rebalancing"),
 		Entry("returns 'failed' when POWEROFF", aiven.PowerOff, brokerapi.Failed, "Last operation failed: service is powered off"),
+		Entry("returns 'failed' when MISSING", aiven.Missing, brokerapi.Failed, "Last operation failed: service does not exist"),
 		Entry("returns 'in progress' by default", aiven.ServiceStatus("foo"), brokerapi.InProgress, "Unknown state: foo"),
 	)
+
+	Describe("node states", func() {
+		It("describes the nodes which are not yet running", func() {
+			state, description := providerStatesMapping(&aiven.Service{
+				State: aiven.Rebuilding,
+				NodeStates: []aiven.NodeState{
+					{Name: "node-1", State: aiven.NodeRunning},
+					{Name: "node-2", State: aiven.NodeSettingUpVM},
+					{
+						Name:  "node-3",
+						State: aiven.NodeSyncingData,
+						ProgressUpdates: []aiven.NodeProgressUpdate{
+							{Phase: "prepare", Completed: true},
+							{Phase: "basebackup", Current: 45, Max: 100, Unit: "bytes_compressed"},
+						},
+					},
+				},
+			})
+			Expect(state).To(Equal(brokerapi.InProgress))
+			Expect(description).To(Equal(
+				"Rebuilding: 1 of 3 nodes running; node-2 is setting up vm; node-3 is syncing data (basebackup 45%)",
+			))
+		})
+
+		It("leaves the description alone once every node is running", func() {
+			_, description := providerStatesMapping(&aiven.Service{
+				State:      aiven.Rebalancing,
+				NodeStates: []aiven.NodeState{{Name: "node-1", State: aiven.NodeRunning}},
+			})
+			Expect(description).To(Equal("Rebalancing"))
+		})
+
+		It("fails a rebuild which has a node timing out", func() {
+			state, description := providerStatesMapping(&aiven.Service{
+				State: aiven.Rebuilding,
+				NodeStates: []aiven.NodeState{
+					{Name: "node-1", State: aiven.NodeRunning},
+					{Name: "node-2", State: aiven.NodeTimingOut},
+				},
+			})
+			Expect(state).To(Equal(brokerapi.Failed))
+			Expect(description).To(Equal("Last operation failed: node node-2 timed out while rebuilding"))
+		})
+	})
 })
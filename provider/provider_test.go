@@ -47,7 +47,7 @@ var _ = Describe("Provider", func() {
 			Catalog: provider.Catalog{
 				Services: []provider.Service{
 					{
-						Service: domain.Service{ID: "uuid-1"},
+						Service: domain.Service{ID: "uuid-1", Name: "opensearch"},
 						Plans: []provider.Plan{
 							{
 								ServicePlan: domain.ServicePlan{
@@ -86,6 +86,77 @@ var _ = Describe("Provider", func() {
 	})
 
 	Describe("Provision", func() {
+		BeforeEach(func() {
+			fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.Missing}, aiven.ErrInstanceDoesNotExist)
+		})
+
+		Context("when the instance already exists", func() {
+			provisionData := provider.ProvisionData{
+				InstanceID: "09e1993e-62e2-4040-adf2-4d3ec741efe6",
+				Service:    domain.Service{ID: "uuid-1", Name: "opensearch"},
+				Plan:       domain.ServicePlan{ID: "uuid-2"},
+				Details: domain.ProvisionDetails{
+					OrganizationGUID: "11084e6f-4cd6-41db-ac2f-bc7e98b29302",
+					SpaceGUID:        "e3ccd653-30b1-4d72-bf11-148e21c1b238",
+				},
+			}
+
+			BeforeEach(func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.Running}, nil)
+				fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{
+					PlanID:         "uuid-2",
+					OrganizationID: "11084e6f-4cd6-41db-ac2f-bc7e98b29302",
+					SpaceID:        "e3ccd653-30b1-4d72-bf11-148e21c1b238",
+				}, nil)
+			})
+
+			It("reports that it already exists if the request is identical", func() {
+				spec, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(spec).To(Equal(domain.ProvisionedServiceSpec{AlreadyExists: true}))
+
+				Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+				_, getServiceArgs := fakeAivenClient.GetServiceArgsForCall(0)
+				Expect(getServiceArgs.ServiceName).To(Equal("env-09e1993e-62e2-4040-adf2-4d3ec741efe6"))
+			})
+
+			It("reports that provisioning is in progress if the service is still being built", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.Rebuilding}, nil)
+
+				spec, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(spec.IsAsync).To(BeTrue())
+				Expect(spec.AlreadyExists).To(BeFalse())
+				Expect(provider.DecodeOperation(spec.OperationData).Type).To(Equal(provider.ProvisionOperation))
+				Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+			})
+
+			It("returns a conflict if the existing instance has a different plan", func() {
+				otherPlanData := provisionData
+				otherPlanData.Plan = domain.ServicePlan{ID: "uuid-3"}
+
+				_, err := aivenProvider.Provision(context.Background(), otherPlanData, true)
+				Expect(err).To(Equal(apiresponses.ErrInstanceAlreadyExists))
+				Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+			})
+
+			It("returns a conflict if the existing instance is in a different space", func() {
+				otherSpaceData := provisionData
+				otherSpaceData.Details.SpaceGUID = "another-space"
+
+				_, err := aivenProvider.Provision(context.Background(), otherSpaceData, true)
+				Expect(err).To(Equal(apiresponses.ErrInstanceAlreadyExists))
+			})
+
+			It("returns an error if the existing service cannot be checked", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, nil, errors.New("some-error"))
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).To(MatchError("some-error"))
+				Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("passes the correct parameters to the Aiven client", func() {
 			provisionData := provider.ProvisionData{
 				InstanceID:    "09e1993e-62e2-4040-adf2-4d3ec741efe6",
@@ -106,7 +177,7 @@ var _ = Describe("Provider", func() {
 
 				userConfig := aiven.UserConfig{}
 				userConfig.OpenSearchVersion = "1"
-				userConfig.IPFilter = []string{"1.2.3.4", "5.6.7.8"}
+				userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4", "5.6.7.8")
 
 				expectedParameters := &aiven.CreateServiceInput{
 					Cloud:       config.Cloud,
@@ -126,7 +197,8 @@ var _ = Describe("Provider", func() {
 						RestoredFromTime:   time.Time{},
 					},
 				}
-				Expect(fakeAivenClient.CreateServiceArgsForCall(0)).To(Equal(expectedParameters))
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs).To(Equal(expectedParameters))
 				os.Unsetenv("IP_WHITELIST")
 			})
 			It("includes custom ip whitelist", func() {
@@ -138,7 +210,7 @@ var _ = Describe("Provider", func() {
 
 				userConfig := aiven.UserConfig{}
 				userConfig.OpenSearchVersion = "1"
-				userConfig.IPFilter = []string{"1.2.3.4", "5.6.7.8", "9.10.11.12"}
+				userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4", "5.6.7.8", "9.10.11.12")
 
 				expectedParameters := &aiven.CreateServiceInput{
 					Cloud:       config.Cloud,
@@ -158,7 +230,8 @@ var _ = Describe("Provider", func() {
 						RestoredFromTime:   time.Time{},
 					},
 				}
-				Expect(fakeAivenClient.CreateServiceArgsForCall(0)).To(Equal(expectedParameters))
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs).To(Equal(expectedParameters))
 				os.Unsetenv("IP_WHITELIST")
 			})
 			It("includes custom ip whitelist with multiple values", func() {
@@ -170,7 +243,7 @@ var _ = Describe("Provider", func() {
 
 				userConfig := aiven.UserConfig{}
 				userConfig.OpenSearchVersion = "1"
-				userConfig.IPFilter = []string{"1.2.3.4", "5.6.7.8", "9.10.11.12", "13.14.15.16"}
+				userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4", "5.6.7.8", "9.10.11.12", "13.14.15.16")
 
 				expectedParameters := &aiven.CreateServiceInput{
 					Cloud:       config.Cloud,
@@ -190,7 +263,8 @@ var _ = Describe("Provider", func() {
 						RestoredFromTime:   time.Time{},
 					},
 				}
-				Expect(fakeAivenClient.CreateServiceArgsForCall(0)).To(Equal(expectedParameters))
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs).To(Equal(expectedParameters))
 				os.Unsetenv("IP_WHITELIST")
 			})
 			It("includes custom ip whitelist when global env is not set", func() {
@@ -202,7 +276,7 @@ var _ = Describe("Provider", func() {
 
 				userConfig := aiven.UserConfig{}
 				userConfig.OpenSearchVersion = "1"
-				userConfig.IPFilter = []string{"9.10.11.12"}
+				userConfig.IPFilter = aiven.NewIPFilter("9.10.11.12")
 
 				expectedParameters := &aiven.CreateServiceInput{
 					Cloud:       config.Cloud,
@@ -222,7 +296,8 @@ var _ = Describe("Provider", func() {
 						RestoredFromTime:   time.Time{},
 					},
 				}
-				Expect(fakeAivenClient.CreateServiceArgsForCall(0)).To(Equal(expectedParameters))
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs).To(Equal(expectedParameters))
 			})
 			It("excludes ip whitelist when not set", func() {
 				os.Unsetenv("IP_WHITELIST")
@@ -233,7 +308,7 @@ var _ = Describe("Provider", func() {
 
 				userConfig := aiven.UserConfig{}
 				userConfig.OpenSearchVersion = "1"
-				userConfig.IPFilter = []string{}
+				userConfig.IPFilter = aiven.NewIPFilter()
 
 				expectedParameters := &aiven.CreateServiceInput{
 					Cloud:       config.Cloud,
@@ -253,7 +328,152 @@ var _ = Describe("Provider", func() {
 						RestoredFromTime:   time.Time{},
 					},
 				}
-				Expect(fakeAivenClient.CreateServiceArgsForCall(0)).To(Equal(expectedParameters))
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs).To(Equal(expectedParameters))
+			})
+			It("includes the PostgreSQL version for pg services", func() {
+				config.Catalog.Services[0].Plans[0].PostgreSQLVersion = "15"
+				pgProvisionData := provisionData
+				pgProvisionData.Service.Name = "pg"
+				pgProvisionData.Details.RawParameters = nil
+
+				_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(1))
+
+				_, createServiceInput := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceInput.ServiceType).To(Equal("pg"))
+				Expect(createServiceInput.UserConfig.PostgreSQLVersion).To(Equal("15"))
+			})
+			It("includes the Redis settings for redis services", func() {
+				config.Catalog.Services[0].Plans[0].RedisMaxmemoryPolicy = "allkeys-lru"
+				config.Catalog.Services[0].Plans[0].RedisPersistence = "off"
+				redisProvisionData := provisionData
+				redisProvisionData.Service.Name = "redis"
+				redisProvisionData.Details.RawParameters = nil
+
+				_, err := aivenProvider.Provision(context.Background(), redisProvisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(1))
+
+				_, createServiceInput := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceInput.ServiceType).To(Equal("redis"))
+				Expect(createServiceInput.UserConfig.RedisMaxmemoryPolicy).To(Equal("allkeys-lru"))
+				Expect(createServiceInput.UserConfig.RedisPersistence).To(Equal("off"))
+			})
+			It("includes the MySQL version for mysql services", func() {
+				config.Catalog.Services[0].Plans[0].MySQLVersion = "8"
+				mysqlProvisionData := provisionData
+				mysqlProvisionData.Service.Name = "mysql"
+				mysqlProvisionData.Details.RawParameters = nil
+
+				_, err := aivenProvider.Provision(context.Background(), mysqlProvisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(1))
+
+				_, createServiceInput := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceInput.ServiceType).To(Equal("mysql"))
+				Expect(createServiceInput.UserConfig.MySQLVersion).To(Equal("8"))
+			})
+			Context("for kafka services", func() {
+				var kafkaProvisionData provider.ProvisionData
+
+				BeforeEach(func() {
+					config.Catalog.Services[0].Plans[0].KafkaVersion = "3.7"
+					kafkaProvisionData = provisionData
+					kafkaProvisionData.Service.Name = "kafka"
+					kafkaProvisionData.Details.RawParameters = nil
+				})
+
+				It("includes the Kafka version", func() {
+					_, err := aivenProvider.Provision(context.Background(), kafkaProvisionData, true)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(1))
+
+					_, createServiceInput := fakeAivenClient.CreateServiceArgsForCall(0)
+					Expect(createServiceInput.ServiceType).To(Equal("kafka"))
+					Expect(createServiceInput.UserConfig.KafkaVersion).To(Equal("3.7"))
+					Expect(fakeAivenClient.CreateKafkaTopicCallCount()).To(Equal(0))
+				})
+
+				It("creates the requested topics", func() {
+					kafkaProvisionData.Details.RawParameters = json.RawMessage(`{"topics": [
+						{"name": "orders", "partitions": 3, "replication": 2, "retention_ms": 86400000},
+						{"name": "events"}
+					]}`)
+
+					_, err := aivenProvider.Provision(context.Background(), kafkaProvisionData, true)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(fakeAivenClient.CreateKafkaTopicCallCount()).To(Equal(2))
+
+					retentionMs := int64(86400000)
+					_, createKafkaTopicArgs := fakeAivenClient.CreateKafkaTopicArgsForCall(0)
+					Expect(createKafkaTopicArgs).To(Equal(&aiven.CreateKafkaTopicInput{
+						ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+						TopicName:   "orders",
+						Partitions:  3,
+						Replication: 2,
+						Config:      aiven.KafkaTopicConfig{RetentionMs: &retentionMs},
+					}))
+					_, createKafkaTopicArgs1 := fakeAivenClient.CreateKafkaTopicArgsForCall(1)
+					Expect(createKafkaTopicArgs1).To(Equal(&aiven.CreateKafkaTopicInput{
+						ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+						TopicName:   "events",
+					}))
+				})
+
+				It("returns an error if a topic name is invalid", func() {
+					kafkaProvisionData.Details.RawParameters = json.RawMessage(`{"topics": [{"name": "orders/eu"}]}`)
+
+					_, err := aivenProvider.Provision(context.Background(), kafkaProvisionData, true)
+					Expect(err).To(MatchError("Invalid topic name: 'orders/eu'"))
+					Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+				})
+
+				It("returns an error if a topic cannot be created", func() {
+					kafkaProvisionData.Details.RawParameters = json.RawMessage(`{"topics": [{"name": "orders"}]}`)
+					fakeAivenClient.CreateKafkaTopicReturns(errors.New("some-error"))
+
+					_, err := aivenProvider.Provision(context.Background(), kafkaProvisionData, true)
+					Expect(err).To(MatchError("some-error"))
+				})
+
+				It("ignores topics which already exist", func() {
+					kafkaProvisionData.Details.RawParameters = json.RawMessage(`{"topics": [{"name": "orders"}, {"name": "events"}]}`)
+					fakeAivenClient.CreateKafkaTopicReturnsOnCall(0, &aiven.APIError{StatusCode: http.StatusConflict})
+
+					_, err := aivenProvider.Provision(context.Background(), kafkaProvisionData, true)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(fakeAivenClient.CreateKafkaTopicCallCount()).To(Equal(2))
+				})
+
+				It("creates the topics when a provision is retried after they failed", func() {
+					kafkaProvisionData.Details.RawParameters = json.RawMessage(`{"topics": [{"name": "orders"}, {"name": "events"}]}`)
+					fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.Rebuilding}, nil)
+					fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{
+						PlanID:         kafkaProvisionData.Plan.ID,
+						OrganizationID: kafkaProvisionData.Details.OrganizationGUID,
+						SpaceID:        kafkaProvisionData.Details.SpaceGUID,
+					}, nil)
+					fakeAivenClient.CreateKafkaTopicReturnsOnCall(0, &aiven.APIError{StatusCode: http.StatusConflict})
+
+					spec, err := aivenProvider.Provision(context.Background(), kafkaProvisionData, true)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(spec.IsAsync).To(BeTrue())
+					Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+					Expect(fakeAivenClient.CreateKafkaTopicCallCount()).To(Equal(2))
+					_, createKafkaTopicArgs := fakeAivenClient.CreateKafkaTopicArgsForCall(1)
+					Expect(createKafkaTopicArgs.TopicName).To(Equal("events"))
+				})
+
+				It("returns an error if topics are requested for another service type", func() {
+					kafkaProvisionData.Service.Name = "opensearch"
+					kafkaProvisionData.Details.RawParameters = json.RawMessage(`{"topics": [{"name": "orders"}]}`)
+
+					_, err := aivenProvider.Provision(context.Background(), kafkaProvisionData, true)
+					Expect(err).To(MatchError("Parameter topics is not supported for opensearch services"))
+					Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+				})
 			})
 			Context("when copying from an existing service", func() {
 				var getServiceReturnData aiven.Service
@@ -287,7 +507,7 @@ var _ = Describe("Provider", func() {
 					fakeAivenClient.GetServiceTagsReturns(&getServiceTagsReturnData, nil)
 					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
 					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(fmt.Errorf("No backups found for 'source-service-name'")))
+					Expect(err).To(Equal(provider.ErrInvalidParameters{Message: "No backups found for 'source-service-name'"}))
 				})
 				It("should get the latest backup", func() {
 					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
@@ -295,7 +515,10 @@ var _ = Describe("Provider", func() {
 					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(1))
-					Expect(fakeAivenClient.ForkServiceArgsForCall(0).UserConfig.BackupName).To(Equal("second backup"))
+					_, forkServiceArgs := fakeAivenClient.ForkServiceArgsForCall(0)
+					Expect(forkServiceArgs.UserConfig.BackupName).To(Equal("second backup"))
+					Expect(forkServiceArgs.Tags.OriginServiceID).To(Equal("source-service-name"))
+					Expect(forkServiceArgs.Tags.RestoredFromPointInTime).To(BeEmpty())
 				})
 				It("should get the latest backup even if the backups are in a weird order", func() {
 					getServiceReturnData.Backups = []aiven.ServiceBackup{}
@@ -322,169 +545,979 @@ var _ = Describe("Provider", func() {
 					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(1))
-					Expect(fakeAivenClient.ForkServiceArgsForCall(0).UserConfig.BackupName).To(Equal("latest"))
+					_, forkServiceArgs := fakeAivenClient.ForkServiceArgsForCall(0)
+					Expect(forkServiceArgs.UserConfig.BackupName).To(Equal("latest"))
 				})
 				It("should error when trying to copy from influxdb to opensearch", func() {
 					getServiceReturnData.ServiceType = "influxdb"
 					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
 					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
 					Expect(err).To(HaveOccurred())
-					Expect(err).To(Equal(fmt.Errorf("You cannot restore an influxdb backup to opensearch")))
+					Expect(err).To(Equal(provider.ErrInvalidParameters{Message: "You cannot restore an influxdb backup to opensearch"}))
 				})
-			})
-		})
+				It("should fork mysql services", func() {
+					getServiceReturnData.ServiceType = "mysql"
+					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
+					fakeAivenClient.GetServiceTagsReturns(&getServiceTagsReturnData, nil)
+					mysqlProvisionData := provisionData
+					mysqlProvisionData.Service.Name = "mysql"
 
-		It("errors if the client errors", func() {
-			provisionData := provider.ProvisionData{
-				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
-			}
-			fakeAivenClient.CreateServiceReturnsOnCall(0, "", errors.New("some-error"))
+					_, err := aivenProvider.Provision(context.Background(), mysqlProvisionData, true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(1))
+					_, forkServiceArgs := fakeAivenClient.ForkServiceArgsForCall(0)
+					Expect(forkServiceArgs.ServiceType).To(Equal("mysql"))
+					Expect(forkServiceArgs.UserConfig.BackupName).To(Equal("second backup"))
+				})
+				It("should fork pg services", func() {
+					getServiceReturnData.ServiceType = "pg"
+					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
+					fakeAivenClient.GetServiceTagsReturns(&getServiceTagsReturnData, nil)
+					pgProvisionData := provisionData
+					pgProvisionData.Service.Name = "pg"
 
-			_, err := aivenProvider.Provision(context.Background(), provisionData, true)
-			Expect(err).To(HaveOccurred())
-		})
-	})
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(1))
+					_, forkServiceArgs := fakeAivenClient.ForkServiceArgsForCall(0)
+					Expect(forkServiceArgs.ServiceType).To(Equal("pg"))
+					Expect(forkServiceArgs.UserConfig.BackupName).To(Equal("second backup"))
+				})
+				It("should error if the source service does not exist", func() {
+					fakeAivenClient.GetServiceReturnsOnCall(0, nil, aiven.ErrInstanceDoesNotExist)
+					fakeAivenClient.GetServiceReturnsOnCall(1, nil, &aiven.APIError{StatusCode: http.StatusNotFound})
 
-	Describe("Deprovision", func() {
-		It("passes the correct parameters to the Aiven client", func() {
-			deprovisionData := provider.DeprovisionData{
-				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
-			}
-			_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
-			Expect(err).ToNot(HaveOccurred())
-			Expect(fakeAivenClient.DeleteServiceCallCount()).To(Equal(1))
+					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+					Expect(err).To(Equal(provider.ErrInvalidParameters{Message: "Service instance 'source-service-name' does not exist"}))
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(0))
+				})
+				It("should error when trying to copy from mysql to opensearch", func() {
+					getServiceReturnData.ServiceType = "mysql"
+					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
+					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+					Expect(err).To(Equal(provider.ErrInvalidParameters{Message: "You cannot restore an mysql backup to opensearch"}))
+				})
+				It("should fork onto the requested plan", func() {
+					getServiceReturnData.Plan = "startup-4"
+					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
+					fakeAivenClient.GetServiceTagsReturns(&getServiceTagsReturnData, nil)
+					largerPlanProvisionData := provisionData
+					largerPlanProvisionData.Plan.ID = "uuid-3"
 
-			expectedParameters := &aiven.DeleteServiceInput{
-				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
-			}
-			Expect(fakeAivenClient.DeleteServiceArgsForCall(0)).To(Equal(expectedParameters))
-		})
+					_, err := aivenProvider.Provision(context.Background(), largerPlanProvisionData, true)
+					Expect(err).NotTo(HaveOccurred())
+					_, forkServiceArgs := fakeAivenClient.ForkServiceArgsForCall(0)
+					Expect(forkServiceArgs.Plan).To(Equal("startup-2"))
+				})
+				It("should fork onto a plan with enough disk space for the backup", func() {
+					config.Catalog.Services[0].Plans[0].DiskSpaceMB = 1
+					getServiceReturnData.Backups[1].Size = 1024 * 1024
+					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
+					fakeAivenClient.GetServiceTagsReturns(&getServiceTagsReturnData, nil)
 
-		It("errors if the client errors", func() {
-			deprovisionData := provider.DeprovisionData{
-				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
-			}
-			fakeAivenClient.DeleteServiceReturnsOnCall(0, errors.New("some-error"))
+					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(1))
+				})
+				It("should return a 422 if the backup does not fit on the requested plan", func() {
+					config.Catalog.Services[0].Plans[0].DiskSpaceMB = 1
+					config.Catalog.Services[0].Plans[0].Name = "tiny"
+					getServiceReturnData.Backups[1].Size = 1024*1024 + 1
+					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
+					fakeAivenClient.GetServiceTagsReturns(&getServiceTagsReturnData, nil)
 
-			_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
-			Expect(err).To(HaveOccurred())
-		})
+					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+					failureResponse, ok := err.(*apiresponses.FailureResponse)
+					Expect(ok).To(BeTrue())
+					Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusUnprocessableEntity))
+					Expect(failureResponse.Error()).To(Equal(
+						"Backup 'second backup' needs 2 MB of disk space but plan 'tiny' only has 1 MB. Please choose a larger plan.",
+					))
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(0))
+				})
+				It("should error for services which cannot be forked", func() {
+					influxDBProvisionData := provisionData
+					influxDBProvisionData.Service.Name = "influxdb"
 
-		It("Returns a specific error if the instance has already been deleted", func() {
-			deprovisionData := provider.DeprovisionData{
-				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
-			}
-			fakeAivenClient.DeleteServiceReturnsOnCall(0, aiven.ErrInstanceDoesNotExist)
+					_, err := aivenProvider.Provision(context.Background(), influxDBProvisionData, true)
+					Expect(err).To(MatchError("Restore from backup not supported for service 'influxdb'"))
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(0))
+				})
+			})
+			Context("when restoring an existing service to a point in time", func() {
+				var (
+					pgProvisionData          provider.ProvisionData
+					getServiceReturnData     aiven.Service
+					getServiceTagsReturnData aiven.ServiceTags
+				)
+				BeforeEach(func() {
+					pgProvisionData = provisionData
+					pgProvisionData.Service.Name = "pg"
+					pgProvisionData.Details.RawParameters = json.RawMessage(`{
+						"restore_from_point_in_time_of": "source-service-name",
+						"restore_from_point_in_time_before": "` + time.Now().UTC().Add(-time.Hour).Format(provider.RestoreFromPointInTimeBeforeTimeFormat) + `"
+					}`)
+					getServiceReturnData = aiven.Service{
+						ServiceType: "pg",
+						Plan:        "startup-4",
+						Backups: []aiven.ServiceBackup{
+							{Name: "newer backup", Time: time.Now().Add(-time.Hour * 24)},
+							{Name: "oldest backup", Time: time.Now().Add(-time.Hour * 48)},
+						},
+					}
+					getServiceTagsReturnData = aiven.ServiceTags{
+						OrganizationID: provisionData.Details.OrganizationGUID,
+						SpaceID:        provisionData.Details.SpaceGUID,
+						PlanID:         provisionData.Plan.ID,
+					}
+					fakeAivenClient.GetServiceReturns(&getServiceReturnData, nil)
+					fakeAivenClient.GetServiceTagsReturns(&getServiceTagsReturnData, nil)
+				})
 
-			_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
-			Expect(err).To(MatchError(apiresponses.ErrInstanceDoesNotExist))
-		})
-	})
+				It("forks the source service at the requested time", func() {
+					target := time.Now().UTC().Add(-time.Hour).Format(provider.RestoreFromPointInTimeBeforeTimeFormat)
+					pgProvisionData.Details.RawParameters = json.RawMessage(`{
+						"restore_from_point_in_time_of": "source-service-name",
+						"restore_from_point_in_time_before": "` + target + `"
+					}`)
 
-	Describe("Bind", func() {
-		const (
-			testInstanceID = "09E1993E-62E2-4040-ADF2-4D3EC741EFE6"
-			testBindingID  = "D26EA3FB-AA78-451C-9ED0-233935ED388F"
-			stubPassword   = "superdupersecret"
-		)
-		var (
-			testESServer    *ghttp.Server
-			testESHost      string
-			testESPort      string
-			versionResponse http.HandlerFunc
-			bindData        provider.BindData
-			bindCtx         context.Context
-			bindCancel      context.CancelFunc
-		)
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(1))
 
-		BeforeEach(func() {
-			testESServer = ghttp.NewTLSServer()
-			http.DefaultClient = testESServer.HTTPTestServer.Client()
-			versionResponse = ghttp.CombineHandlers(
-				ghttp.VerifyRequest("GET", "/"),
-				ghttp.VerifyBasicAuth(testBindingID, stubPassword),
-				ghttp.RespondWith(200, `{"version":{"number":"1.2.3"}}`),
-			)
-			testESServer.AppendHandlers(versionResponse)
+					_, forkServiceArgs := fakeAivenClient.ForkServiceArgsForCall(0)
+					Expect(forkServiceArgs.Plan).To(Equal("startup-1"))
+					Expect(forkServiceArgs.ServiceType).To(Equal("pg"))
+					Expect(forkServiceArgs.UserConfig.BackupServiceName).To(Equal("env-source-service-name"))
+					Expect(forkServiceArgs.UserConfig.RecoveryTargetTime).To(Equal(target))
+					Expect(forkServiceArgs.UserConfig.BackupName).To(BeEmpty())
+					Expect(forkServiceArgs.Tags.RestoredFromBackup).To(Equal("true"))
+					Expect(forkServiceArgs.Tags.OriginServiceID).To(Equal("source-service-name"))
+					Expect(forkServiceArgs.Tags.RestoredFromTime.Format(provider.RestoreFromPointInTimeBeforeTimeFormat)).To(Equal(target))
+					Expect(forkServiceArgs.Tags.RestoredFromPointInTime).To(Equal("true"))
+				})
 
-			esURL, err := url.Parse(testESServer.URL())
-			Expect(err).NotTo(HaveOccurred())
-			parts := strings.SplitN(esURL.Host, ":", 2)
-			Expect(parts).To(HaveLen(2))
-			testESHost, testESPort = parts[0], parts[1]
+				It("errors if the time is older than the oldest backup", func() {
+					pgProvisionData.Details.RawParameters = json.RawMessage(`{
+						"restore_from_point_in_time_of": "source-service-name",
+						"restore_from_point_in_time_before": "` + time.Now().UTC().Add(-time.Hour*72).Format(provider.RestoreFromPointInTimeBeforeTimeFormat) + `"
+					}`)
 
-			fakeAivenClient.CreateServiceUserReturnsOnCall(0, stubPassword, nil)
-			fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
-				ServiceUriParams: aiven.ServiceUriParams{
-					Host: testESHost,
-					Port: testESPort,
-				},
-				ServiceType: "opensearch",
-			}, nil)
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).To(BeAssignableToTypeOf(provider.ErrInvalidParameters{}))
+					Expect(err.Error()).To(HavePrefix("Parameter restore_from_point_in_time_before must be between "))
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(0))
+				})
 
-			bindCtx, bindCancel = context.WithTimeout(context.Background(), 5*time.Second)
-			bindData = provider.BindData{
-				InstanceID: testInstanceID,
-				BindingID:  testBindingID,
-			}
-		})
+				It("errors if the backup the restore starts from does not fit on the requested plan", func() {
+					config.Catalog.Services[0].Plans[0].DiskSpaceMB = 1024
+					getServiceReturnData.Backups[0].Size = 2048 * 1024 * 1024
 
-		AfterEach(func() {
-			if testESServer != nil {
-				testESServer.Close()
-			}
-			bindCancel()
-		})
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					failureResponse, ok := err.(*apiresponses.FailureResponse)
+					Expect(ok).To(BeTrue())
+					Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusUnprocessableEntity))
+					Expect(failureResponse.Error()).To(HavePrefix("Backup 'newer backup' needs 2048 MB"))
+				})
 
-		It("passes the correct parameters to the Aiven client", func() {
-			actualBinding, err := aivenProvider.Bind(bindCtx, bindData)
-			Expect(err).ToNot(HaveOccurred())
+				It("errors if the time is in the future", func() {
+					pgProvisionData.Details.RawParameters = json.RawMessage(`{
+						"restore_from_point_in_time_of": "source-service-name",
+						"restore_from_point_in_time_before": "` + time.Now().UTC().Add(time.Hour).Format(provider.RestoreFromPointInTimeBeforeTimeFormat) + `"
+					}`)
 
-			expectedCreateServiceUserParameters := &aiven.CreateServiceUserInput{
-				ServiceName: "env-" + strings.ToLower(testInstanceID),
-				Username:    testBindingID,
-			}
-			Expect(fakeAivenClient.CreateServiceUserArgsForCall(0)).To(Equal(expectedCreateServiceUserParameters))
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).To(BeAssignableToTypeOf(provider.ErrInvalidParameters{}))
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(0))
+				})
 
-			expectedGetServiceConnectionDetailsParameters := &aiven.GetServiceInput{
-				ServiceName: "env-" + strings.ToLower(testInstanceID),
-			}
-			Expect(fakeAivenClient.GetServiceArgsForCall(0)).To(Equal(expectedGetServiceConnectionDetailsParameters))
+				It("errors if the time cannot be parsed", func() {
+					pgProvisionData.Details.RawParameters = json.RawMessage(`{
+						"restore_from_point_in_time_of": "source-service-name",
+						"restore_from_point_in_time_before": "yesterday"
+					}`)
 
-			expectedCreds := provider.Credentials{}
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).To(BeAssignableToTypeOf(provider.ErrInvalidParameters{}))
+					Expect(err.Error()).To(HavePrefix("Parameter restore_from_point_in_time_before should be a date and a time"))
+				})
 
-			expectedCreds.URI = fmt.Sprintf(
-				"https://%s:%s@%s:%s",
-				testBindingID, stubPassword, testESHost, testESPort,
-			)
-			expectedCreds.Hostname = testESHost
-			expectedCreds.Port = testESPort
-			expectedCreds.Username = testBindingID
-			expectedCreds.Password = stubPassword
+				It("errors if the time is missing", func() {
+					pgProvisionData.Details.RawParameters = json.RawMessage(`{"restore_from_point_in_time_of": "source-service-name"}`)
 
-			expectedBinding := domain.Binding{Credentials: expectedCreds}
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).To(MatchError("Parameter restore_from_point_in_time_before must be set when using restore_from_point_in_time_of"))
+				})
 
-			Expect(actualBinding).To(Equal(expectedBinding))
-		})
+				It("errors if only the time is given", func() {
+					pgProvisionData.Details.RawParameters = json.RawMessage(`{"restore_from_point_in_time_before": "2024-01-01 00:00:00"}`)
 
-		It("errors if the client fails to create the service user", func() {
-			fakeAivenClient.CreateServiceUserReturnsOnCall(0, "", errors.New("some-error"))
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).To(MatchError("Parameter restore_from_point_in_time_before should be used with restore_from_point_in_time_of"))
+				})
 
-			_, err := aivenProvider.Bind(bindCtx, bindData)
-			Expect(err).To(HaveOccurred())
+				It("errors if combined with a restore from the latest backup", func() {
+					pgProvisionData.Details.RawParameters = json.RawMessage(`{
+						"restore_from_point_in_time_of": "source-service-name",
+						"restore_from_point_in_time_before": "2024-01-01 00:00:00",
+						"restore_from_latest_backup_of": "source-service-name"
+					}`)
+
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).To(MatchError("Parameters restore_from_latest_backup_of and restore_from_point_in_time_of cannot be used together"))
+				})
+
+				It("errors if the source service is in another space", func() {
+					getServiceTagsReturnData.SpaceID = "another-space"
+
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).To(BeAssignableToTypeOf(provider.ErrInvalidParameters{}))
+					Expect(fakeAivenClient.ForkServiceCallCount()).To(Equal(0))
+				})
+
+				It("errors for services which cannot be restored to a point in time", func() {
+					pgProvisionData.Service.Name = "opensearch"
+
+					_, err := aivenProvider.Provision(context.Background(), pgProvisionData, true)
+					Expect(err).To(MatchError("Point in time restore not supported for service 'opensearch'"))
+					Expect(fakeAivenClient.GetServiceTagsCallCount()).To(Equal(0))
+				})
+			})
 		})
 
-		It("errors if the client fails to get the service", func() {
-			fakeAivenClient.GetServiceReturnsOnCall(0, nil, errors.New("some-error"))
+		It("does not tag new services for deletion", func() {
+			provisionData := provider.ProvisionData{
+				InstanceID: "09e1993e-62e2-4040-adf2-4d3ec741efe6",
+				Service:    domain.Service{ID: "uuid-1", Name: "opensearch"},
+				Plan:       domain.ServicePlan{ID: "uuid-2"},
+			}
 
-			_, err := aivenProvider.Bind(bindCtx, bindData)
-			Expect(err).To(HaveOccurred())
+			_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+			Expect(err).ToNot(HaveOccurred())
+			_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+			sentTags, err := json.Marshal(createServiceArgs.Tags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(sentTags)).ToNot(ContainSubstring("delete_after"))
 		})
 
-		Describe("polling ES until the credentials work", func() {
-			var (
-				unauthorizedResponse http.HandlerFunc
-			)
+		Context("when setting a maintenance window", func() {
+			var provisionData provider.ProvisionData
+
+			BeforeEach(func() {
+				provisionData = provider.ProvisionData{
+					InstanceID: "09e1993e-62e2-4040-adf2-4d3ec741efe6",
+					Service:    domain.Service{ID: "uuid-1", Name: "opensearch"},
+					Plan:       domain.ServicePlan{ID: "uuid-2"},
+				}
+			})
+
+			It("does not set a maintenance window by default", func() {
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.Maintenance).To(BeNil())
+			})
+
+			It("uses the default maintenance window of the plan", func() {
+				config.Catalog.Services[0].Plans[0].MaintenanceWindow = &provider.MaintenanceWindow{DOW: "Sunday", Time: "03:00"}
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.Maintenance).To(Equal(&aiven.Maintenance{DOW: "sunday", Time: "03:00:00"}))
+			})
+
+			It("prefers the maintenance window requested by the user", func() {
+				config.Catalog.Services[0].Plans[0].MaintenanceWindow = &provider.MaintenanceWindow{DOW: "sunday", Time: "03:00"}
+				provisionData.Details.RawParameters = json.RawMessage(`{"maintenance_window": {"dow": "wednesday", "time": "22:30:00"}}`)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.Maintenance).To(Equal(&aiven.Maintenance{DOW: "wednesday", Time: "22:30:00"}))
+			})
+
+			DescribeTable("rejects invalid maintenance windows",
+				func(parameters, expectedError string) {
+					provisionData.Details.RawParameters = json.RawMessage(parameters)
+
+					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+					Expect(err).To(MatchError(expectedError))
+					Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+				},
+				Entry("unknown day",
+					`{"maintenance_window": {"dow": "funday", "time": "03:00"}}`,
+					"Invalid maintenance window day: 'funday'. It must be a day of the week, such as 'sunday'"),
+				Entry("missing day",
+					`{"maintenance_window": {"time": "03:00"}}`,
+					"Invalid maintenance window day: ''. It must be a day of the week, such as 'sunday'"),
+				Entry("invalid time",
+					`{"maintenance_window": {"dow": "sunday", "time": "25:00"}}`,
+					"Invalid maintenance window time: '25:00'. It must be a time in UTC, such as '03:00'"),
+				Entry("missing time",
+					`{"maintenance_window": {"dow": "sunday"}}`,
+					"Invalid maintenance window time: ''. It must be a time in UTC, such as '03:00'"),
+			)
+		})
+
+		Context("when setting termination protection", func() {
+			var provisionData provider.ProvisionData
+
+			BeforeEach(func() {
+				provisionData = provider.ProvisionData{
+					InstanceID: "09e1993e-62e2-4040-adf2-4d3ec741efe6",
+					Service:    domain.Service{ID: "uuid-1", Name: "opensearch"},
+					Plan:       domain.ServicePlan{ID: "uuid-2"},
+				}
+			})
+
+			It("does not protect the service by default", func() {
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.TerminationProtection).To(BeFalse())
+			})
+
+			It("uses the default of the plan", func() {
+				config.Catalog.Services[0].Plans[0].TerminationProtection = true
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.TerminationProtection).To(BeTrue())
+			})
+
+			It("lets the user turn off the default of the plan", func() {
+				config.Catalog.Services[0].Plans[0].TerminationProtection = true
+				provisionData.Details.RawParameters = json.RawMessage(`{"termination_protection": false}`)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.TerminationProtection).To(BeFalse())
+			})
+
+			It("lets the user turn on termination protection", func() {
+				provisionData.Details.RawParameters = json.RawMessage(`{"termination_protection": true}`)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.TerminationProtection).To(BeTrue())
+			})
+		})
+
+		Context("when the operator has set an IP filter policy", func() {
+			var provisionData provider.ProvisionData
+
+			BeforeEach(func() {
+				provisionData = provider.ProvisionData{
+					InstanceID: "09e1993e-62e2-4040-adf2-4d3ec741efe6",
+					Service:    domain.Service{ID: "uuid-1", Name: "opensearch"},
+					Plan:       domain.ServicePlan{ID: "uuid-2"},
+				}
+				config.IPFilterPolicy = provider.IPFilterPolicy{
+					MandatoryRanges:     []string{"192.0.2.0/24"},
+					ForbiddenRanges:     []string{"169.254.0.0/16"},
+					MinIPv4PrefixLength: 8,
+					MinIPv6PrefixLength: 32,
+				}
+			})
+
+			It("puts the mandatory ranges in front of the user's entries", func() {
+				provisionData.Details.RawParameters = json.RawMessage(`{"ip_filter": "198.51.100.7,192.0.2.0/24"}`)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.UserConfig.IPFilter).To(Equal(aiven.NewIPFilter("192.0.2.0/24", "198.51.100.7")))
+			})
+
+			It("adds the mandatory ranges of the plan", func() {
+				config.Catalog.Services[0].Plans[0].IPFilterPolicy.MandatoryRanges = []string{"203.0.113.0/24"}
+				provisionData.Details.RawParameters = json.RawMessage(`{"ip_filter": "198.51.100.7"}`)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+				_, createServiceArgs := fakeAivenClient.CreateServiceArgsForCall(0)
+				Expect(createServiceArgs.UserConfig.IPFilter).To(Equal(aiven.NewIPFilter("192.0.2.0/24", "203.0.113.0/24", "198.51.100.7")))
+			})
+
+			DescribeTable("rejects entries which overlap a forbidden range",
+				func(ipFilter, expectedError string) {
+					provisionData.Details.RawParameters = json.RawMessage(`{"ip_filter": "` + ipFilter + `"}`)
+
+					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+					Expect(err).To(Equal(provider.ErrInvalidParameters{Message: expectedError}))
+					Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+				},
+				Entry("an address in the range",
+					"198.51.100.7,169.254.169.254",
+					"IP filter entry '169.254.169.254' is not allowed because it overlaps the forbidden range 169.254.0.0/16"),
+				Entry("a range which includes the range",
+					"169.0.0.0/8",
+					"IP filter entry '169.0.0.0/8' is not allowed because it overlaps the forbidden range 169.254.0.0/16"),
+				Entry("the range split into halves",
+					"169.254.0.0/17,169.254.128.0/17",
+					"IP filter entry '169.254.0.0/17' is not allowed because it overlaps the forbidden range 169.254.0.0/16"),
+			)
+
+			DescribeTable("rejects entries which are wider than the minimum prefix length",
+				func(ipFilter, expectedError string) {
+					provisionData.Details.RawParameters = json.RawMessage(`{"ip_filter": "` + ipFilter + `"}`)
+
+					_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+					Expect(err).To(Equal(provider.ErrInvalidParameters{Message: expectedError}))
+					Expect(fakeAivenClient.CreateServiceCallCount()).To(Equal(0))
+				},
+				Entry("the whole IPv4 internet",
+					"0.0.0.0/0",
+					"IP filter entry '0.0.0.0/0' is not allowed because it is wider than /8"),
+				Entry("the IPv4 internet split into halves",
+					"0.0.0.0/1,128.0.0.0/1",
+					"IP filter entry '0.0.0.0/1' is not allowed because it is wider than /8"),
+				Entry("the whole IPv6 internet",
+					"198.51.100.7,::/0",
+					"IP filter entry '::/0' is not allowed because it is wider than /32"),
+				Entry("the IPv6 internet split into halves",
+					"::/1,8000::/1",
+					"IP filter entry '::/1' is not allowed because it is wider than /32"),
+			)
+
+			It("allows entries which are as wide as the minimum prefix length", func() {
+				provisionData.Details.RawParameters = json.RawMessage(`{"ip_filter": "10.0.0.0/8,2001:db8::/32"}`)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("rejects the forbidden ranges of the plan", func() {
+				config.Catalog.Services[0].Plans[0].IPFilterPolicy.ForbiddenRanges = []string{"10.0.0.0/8"}
+				provisionData.Details.RawParameters = json.RawMessage(`{"ip_filter": "10.1.0.0/16"}`)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).To(MatchError("IP filter entry '10.1.0.0/16' is not allowed because it overlaps the forbidden range 10.0.0.0/8"))
+			})
+
+			It("uses the stricter minimum prefix length of the plan", func() {
+				config.Catalog.Services[0].Plans[0].IPFilterPolicy.MinIPv4PrefixLength = 24
+				provisionData.Details.RawParameters = json.RawMessage(`{"ip_filter": "10.0.0.0/16"}`)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).To(MatchError("IP filter entry '10.0.0.0/16' is not allowed because it is wider than /24"))
+			})
+
+			It("requires an IP filter when Aiven's default would break the policy", func() {
+				config.IPFilterPolicy.MandatoryRanges = nil
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).To(MatchError("Parameter ip_filter is required for this plan"))
+			})
+
+			It("requires an IP filter when only a minimum prefix length is set", func() {
+				config.IPFilterPolicy = provider.IPFilterPolicy{MinIPv4PrefixLength: 24}
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).To(MatchError("Parameter ip_filter is required for this plan"))
+			})
+		})
+
+		It("errors if the client errors", func() {
+			provisionData := provider.ProvisionData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			}
+			fakeAivenClient.CreateServiceReturnsOnCall(0, "", errors.New("some-error"))
+
+			_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("when Aiven rejects the request", func() {
+			provisionData := provider.ProvisionData{
+				InstanceID: "09e1993e-62e2-4040-adf2-4d3ec741efe6",
+				Service:    domain.Service{ID: "uuid-1", Name: "opensearch"},
+				Plan:       domain.ServicePlan{ID: "uuid-2"},
+			}
+
+			It("returns ErrInstanceAlreadyExists if the service name is taken", func() {
+				fakeAivenClient.CreateServiceReturns("", &aiven.APIError{
+					StatusCode: http.StatusConflict,
+					Messages:   []string{"Service name is already in use"},
+				})
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).To(Equal(apiresponses.ErrInstanceAlreadyExists))
+			})
+
+			It("returns a 422 if the project quota is exceeded", func() {
+				fakeAivenClient.CreateServiceReturns("", &aiven.APIError{
+					StatusCode: http.StatusForbidden,
+					Messages:   []string{"Project service quota exceeded"},
+				})
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				failureResponse := &apiresponses.FailureResponse{}
+				Expect(errors.As(err, &failureResponse)).To(BeTrue())
+				Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusUnprocessableEntity))
+				Expect(failureResponse.LoggerAction()).To(Equal("aiven-quota-exceeded"))
+				Expect(failureResponse.Error()).To(Equal("The Aiven project has run out of quota, please contact support"))
+				Expect(err.Error()).To(ContainSubstring("403 status code returned from Aiven"))
+			})
+
+			It("returns a 503 if Aiven is unavailable", func() {
+				fakeAivenClient.CreateServiceReturns("", &aiven.APIError{
+					StatusCode: http.StatusBadGateway,
+				})
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				failureResponse := &apiresponses.FailureResponse{}
+				Expect(errors.As(err, &failureResponse)).To(BeTrue())
+				Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusServiceUnavailable))
+			})
+
+			It("returns other errors unchanged", func() {
+				apiErr := &aiven.APIError{StatusCode: http.StatusBadRequest}
+				fakeAivenClient.CreateServiceReturns("", apiErr)
+
+				_, err := aivenProvider.Provision(context.Background(), provisionData, true)
+				Expect(err).To(Equal(apiErr))
+			})
+		})
+	})
+
+	Describe("Deprovision", func() {
+		It("passes the correct parameters to the Aiven client", func() {
+			deprovisionData := provider.DeprovisionData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			}
+			operationData, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeAivenClient.DeleteServiceCallCount()).To(Equal(1))
+			Expect(provider.DecodeOperation(operationData).Type).To(Equal(provider.DeprovisionOperation))
+
+			expectedParameters := &aiven.DeleteServiceInput{
+				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+			}
+			_, deleteServiceArgs := fakeAivenClient.DeleteServiceArgsForCall(0)
+			Expect(deleteServiceArgs).To(Equal(expectedParameters))
+		})
+
+		It("errors if the client errors", func() {
+			deprovisionData := provider.DeprovisionData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			}
+			fakeAivenClient.DeleteServiceReturnsOnCall(0, errors.New("some-error"))
+
+			_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Returns a specific error if the instance has already been deleted", func() {
+			deprovisionData := provider.DeprovisionData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			}
+			fakeAivenClient.DeleteServiceReturnsOnCall(0, aiven.ErrInstanceDoesNotExist)
+
+			_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+			Expect(err).To(MatchError(apiresponses.ErrInstanceDoesNotExist))
+		})
+
+		It("returns a specific error if the instance cannot be found", func() {
+			deprovisionData := provider.DeprovisionData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			}
+			fakeAivenClient.GetServiceReturns(nil, aiven.ErrInstanceDoesNotExist)
+
+			_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+			Expect(err).To(MatchError(apiresponses.ErrInstanceDoesNotExist))
+			Expect(fakeAivenClient.DeleteServiceCallCount()).To(Equal(0))
+		})
+
+		It("refuses to delete an instance with termination protection", func() {
+			deprovisionData := provider.DeprovisionData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			}
+			fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, TerminationProtection: true}, nil)
+
+			_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+			Expect(err).To(Equal(provider.ErrTerminationProtectionEnabled))
+			Expect(err.(*apiresponses.FailureResponse).ValidatedStatusCode(nil)).To(Equal(http.StatusUnprocessableEntity))
+			Expect(fakeAivenClient.DeleteServiceCallCount()).To(Equal(0))
+			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
+		})
+
+		Context("when soft deletion is configured", func() {
+			var deprovisionData provider.DeprovisionData
+
+			BeforeEach(func() {
+				config.SoftDeleteGracePeriodHours = 72
+				deprovisionData = provider.DeprovisionData{
+					InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				}
+				fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{PlanID: "uuid-2", SpaceID: "space-id"}, nil)
+			})
+
+			It("powers off the service and tags it for deletion instead of deleting it", func() {
+				operationData, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeAivenClient.DeleteServiceCallCount()).To(Equal(0))
+				Expect(provider.DecodeOperation(operationData).Type).To(Equal(provider.SoftDeleteOperation))
+
+				Expect(fakeAivenClient.UpdateServiceTagsCallCount()).To(Equal(1))
+				_, updateServiceTagsArgs := fakeAivenClient.UpdateServiceTagsArgsForCall(0)
+				Expect(updateServiceTagsArgs.ServiceName).To(Equal("env-09e1993e-62e2-4040-adf2-4d3ec741efe6"))
+				Expect(updateServiceTagsArgs.Tags.PlanID).To(Equal("uuid-2"))
+				Expect(updateServiceTagsArgs.Tags.SpaceID).To(Equal("space-id"))
+				Expect(updateServiceTagsArgs.Tags.DeleteAfter).ToNot(BeNil())
+				Expect(*updateServiceTagsArgs.Tags.DeleteAfter).To(BeTemporally("~", time.Now().Add(72*time.Hour), time.Minute))
+
+				Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
+				_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+				Expect(updateServiceArgs.ServiceName).To(Equal("env-09e1993e-62e2-4040-adf2-4d3ec741efe6"))
+				Expect(updateServiceArgs.Powered).ToNot(BeNil())
+				Expect(*updateServiceArgs.Powered).To(BeFalse())
+			})
+
+			It("returns ErrInstanceDoesNotExist if Aiven cannot find the service's tags", func() {
+				fakeAivenClient.GetServiceTagsReturns(nil, &aiven.APIError{StatusCode: http.StatusNotFound})
+
+				_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+				Expect(err).To(Equal(apiresponses.ErrInstanceDoesNotExist))
+				Expect(fakeAivenClient.UpdateServiceTagsCallCount()).To(Equal(0))
+				Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
+			})
+
+			It("does not power off the service if it cannot be tagged", func() {
+				fakeAivenClient.UpdateServiceTagsReturns("", errors.New("some-error"))
+
+				_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+				Expect(err).To(HaveOccurred())
+				Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
+			})
+
+			It("still respects termination protection", func() {
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, TerminationProtection: true}, nil)
+
+				_, err := aivenProvider.Deprovision(context.Background(), deprovisionData)
+				Expect(err).To(Equal(provider.ErrTerminationProtectionEnabled))
+				Expect(fakeAivenClient.UpdateServiceTagsCallCount()).To(Equal(0))
+				Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Bind", func() {
+		const (
+			testInstanceID = "09E1993E-62E2-4040-ADF2-4D3EC741EFE6"
+			testBindingID  = "D26EA3FB-AA78-451C-9ED0-233935ED388F"
+			stubPassword   = "superdupersecret"
+		)
+		var (
+			testESServer    *ghttp.Server
+			testESHost      string
+			testESPort      string
+			versionResponse http.HandlerFunc
+			bindData        provider.BindData
+			bindCtx         context.Context
+			bindCancel      context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			testESServer = ghttp.NewTLSServer()
+			http.DefaultClient = testESServer.HTTPTestServer.Client()
+			versionResponse = ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/"),
+				ghttp.VerifyBasicAuth(testBindingID, stubPassword),
+				ghttp.RespondWith(200, `{"version":{"number":"1.2.3"}}`),
+			)
+			testESServer.AppendHandlers(versionResponse)
+
+			esURL, err := url.Parse(testESServer.URL())
+			Expect(err).NotTo(HaveOccurred())
+			parts := strings.SplitN(esURL.Host, ":", 2)
+			Expect(parts).To(HaveLen(2))
+			testESHost, testESPort = parts[0], parts[1]
+
+			fakeAivenClient.GetServiceUserReturns(nil, aiven.ErrInstanceUserDoesNotExist)
+			fakeAivenClient.CreateServiceUserReturnsOnCall(0, &aiven.User{
+				Username: testBindingID,
+				Password: stubPassword,
+			}, nil)
+			fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+				ServiceUriParams: aiven.ServiceUriParams{
+					Host: testESHost,
+					Port: testESPort,
+				},
+				ServiceType: "opensearch",
+			}, nil)
+
+			bindCtx, bindCancel = context.WithTimeout(context.Background(), 5*time.Second)
+			bindData = provider.BindData{
+				InstanceID: testInstanceID,
+				BindingID:  testBindingID,
+				Details:    domain.BindDetails{ServiceID: "uuid-1"},
+			}
+		})
+
+		AfterEach(func() {
+			if testESServer != nil {
+				testESServer.Close()
+			}
+			bindCancel()
+		})
+
+		It("passes the correct parameters to the Aiven client", func() {
+			actualBinding, err := aivenProvider.Bind(bindCtx, bindData, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectedCreateServiceUserParameters := &aiven.CreateServiceUserInput{
+				ServiceName: "env-" + strings.ToLower(testInstanceID),
+				Username:    testBindingID,
+			}
+			_, createServiceUserArgs := fakeAivenClient.CreateServiceUserArgsForCall(0)
+			Expect(createServiceUserArgs).To(Equal(expectedCreateServiceUserParameters))
+
+			expectedGetServiceConnectionDetailsParameters := &aiven.GetServiceInput{
+				ServiceName: "env-" + strings.ToLower(testInstanceID),
+			}
+			_, getServiceArgs := fakeAivenClient.GetServiceArgsForCall(0)
+			Expect(getServiceArgs).To(Equal(expectedGetServiceConnectionDetailsParameters))
+
+			expectedCreds := provider.Credentials{}
+
+			expectedCreds.URI = fmt.Sprintf(
+				"https://%s:%s@%s:%s",
+				testBindingID, stubPassword, testESHost, testESPort,
+			)
+			expectedCreds.Hostname = testESHost
+			expectedCreds.Port = testESPort
+			expectedCreds.Username = testBindingID
+			expectedCreds.Password = stubPassword
+
+			expectedBinding := domain.Binding{Credentials: expectedCreds}
+
+			Expect(actualBinding).To(Equal(expectedBinding))
+		})
+
+		It("errors if the client fails to create the service user", func() {
+			fakeAivenClient.CreateServiceUserReturnsOnCall(0, nil, errors.New("some-error"))
+
+			_, err := aivenProvider.Bind(bindCtx, bindData, false)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("errors if the client fails to get the service", func() {
+			fakeAivenClient.GetServiceReturnsOnCall(0, nil, errors.New("some-error"))
+
+			_, err := aivenProvider.Bind(bindCtx, bindData, false)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns ErrBindingAlreadyExists if the service user already exists", func() {
+			fakeAivenClient.CreateServiceUserReturnsOnCall(0, nil, &aiven.APIError{StatusCode: http.StatusConflict})
+
+			_, err := aivenProvider.Bind(bindCtx, bindData, false)
+			Expect(err).To(Equal(apiresponses.ErrBindingAlreadyExists))
+		})
+
+		Context("when async bindings are allowed", func() {
+			It("creates the user and returns without waiting for it to work", func() {
+				actualBinding, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeAivenClient.CreateServiceUserCallCount()).To(Equal(1))
+				Expect(fakeAivenClient.GetServiceCallCount()).To(Equal(0))
+				Expect(testESServer.ReceivedRequests()).To(BeEmpty())
+				Expect(actualBinding.IsAsync).To(BeTrue())
+				operation := provider.DecodeOperation(actualBinding.OperationData)
+				Expect(operation.Type).To(Equal(provider.BindOperation))
+				Expect(operation.StartedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+		})
+
+		It("errors if acls are requested for a service other than kafka", func() {
+			bindData.Details.RawParameters = json.RawMessage(`{"acls": [{"topic": "orders", "permission": "read"}]}`)
+
+			_, err := aivenProvider.Bind(bindCtx, bindData, true)
+			Expect(err).To(MatchError("Parameter acls is not supported for opensearch services"))
+			Expect(fakeAivenClient.CreateServiceUserCallCount()).To(Equal(0))
+		})
+
+		Context("for kafka services", func() {
+			BeforeEach(func() {
+				config.Catalog.Services = append(config.Catalog.Services, provider.Service{
+					Service: domain.Service{ID: "kafka-uuid", Name: "kafka"},
+				})
+				bindData.Details.ServiceID = "kafka-uuid"
+			})
+
+			It("grants the user access to every topic by default", func() {
+				_, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeAivenClient.CreateKafkaACLCallCount()).To(Equal(1))
+				_, createKafkaACLArgs := fakeAivenClient.CreateKafkaACLArgsForCall(0)
+				Expect(createKafkaACLArgs).To(Equal(&aiven.CreateKafkaACLInput{
+					ServiceName: "env-" + strings.ToLower(testInstanceID),
+					Permission:  "readwrite",
+					Topic:       "*",
+					Username:    testBindingID,
+				}))
+			})
+
+			It("grants the user the requested ACLs", func() {
+				bindData.Details.RawParameters = json.RawMessage(`{"acls": [
+					{"topic": "orders", "permission": "read"},
+					{"topic": "events-*", "permission": "write"}
+				]}`)
+
+				_, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeAivenClient.CreateKafkaACLCallCount()).To(Equal(2))
+				_, createKafkaACLArgs := fakeAivenClient.CreateKafkaACLArgsForCall(0)
+				Expect(createKafkaACLArgs.Topic).To(Equal("orders"))
+				Expect(createKafkaACLArgs.Permission).To(Equal("read"))
+				_, createKafkaACLArgs1 := fakeAivenClient.CreateKafkaACLArgsForCall(1)
+				Expect(createKafkaACLArgs1.Topic).To(Equal("events-*"))
+				Expect(createKafkaACLArgs1.Permission).To(Equal("write"))
+			})
+
+			It("errors if an ACL permission is invalid", func() {
+				bindData.Details.RawParameters = json.RawMessage(`{"acls": [{"topic": "orders", "permission": "everything"}]}`)
+
+				_, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).To(MatchError("Invalid ACL permission: 'everything'"))
+				Expect(fakeAivenClient.CreateServiceUserCallCount()).To(Equal(0))
+			})
+
+			It("errors if the client fails to create an ACL", func() {
+				fakeAivenClient.CreateKafkaACLReturns(nil, errors.New("some-error"))
+
+				_, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).To(MatchError("some-error"))
+			})
+
+			It("removes the user and its ACLs if an ACL cannot be created", func() {
+				bindData.Details.RawParameters = json.RawMessage(`{"acls": [
+					{"topic": "orders", "permission": "read"},
+					{"topic": "events-*", "permission": "write"}
+				]}`)
+				fakeAivenClient.CreateKafkaACLReturnsOnCall(1, nil, errors.New("some-error"))
+				fakeAivenClient.ListKafkaACLsReturns([]aiven.KafkaACL{
+					{ID: "acl1", Topic: "orders", Permission: "read", Username: testBindingID},
+					{ID: "acl2", Topic: "*", Permission: "admin", Username: "someone-else"},
+				}, nil)
+
+				_, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).To(MatchError("some-error"))
+
+				Expect(fakeAivenClient.DeleteKafkaACLCallCount()).To(Equal(1))
+				_, deleteKafkaACLArgs := fakeAivenClient.DeleteKafkaACLArgsForCall(0)
+				Expect(deleteKafkaACLArgs.ACLID).To(Equal("acl1"))
+				Expect(fakeAivenClient.DeleteServiceUserCallCount()).To(Equal(1))
+				_, deleteServiceUserArgs := fakeAivenClient.DeleteServiceUserArgsForCall(0)
+				Expect(deleteServiceUserArgs.Username).To(Equal(testBindingID))
+			})
+
+			It("returns the original error if the user cannot be removed", func() {
+				fakeAivenClient.CreateKafkaACLReturns(nil, errors.New("some-error"))
+				fakeAivenClient.DeleteServiceUserReturns("", errors.New("delete-error"))
+
+				_, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).To(MatchError("some-error"))
+			})
+
+			Context("when the user already exists", func() {
+				BeforeEach(func() {
+					fakeAivenClient.GetServiceUserReturns(&aiven.User{
+						Username: testBindingID,
+						Password: stubPassword,
+					}, nil)
+					bindData.Details.RawParameters = json.RawMessage(`{"acls": [
+						{"topic": "orders", "permission": "read"},
+						{"topic": "events-*", "permission": "write"}
+					]}`)
+				})
+
+				It("returns the existing credentials if the ACLs are the same", func() {
+					fakeAivenClient.ListKafkaACLsReturns([]aiven.KafkaACL{
+						{ID: "acl1", Topic: "events-*", Permission: "write", Username: testBindingID},
+						{ID: "acl2", Topic: "*", Permission: "admin", Username: "someone-else"},
+						{ID: "acl3", Topic: "orders", Permission: "read", Username: testBindingID},
+					}, nil)
+
+					binding, err := aivenProvider.Bind(bindCtx, bindData, true)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(binding.AlreadyExists).To(BeTrue())
+					Expect(fakeAivenClient.CreateServiceUserCallCount()).To(Equal(0))
+					Expect(fakeAivenClient.CreateKafkaACLCallCount()).To(Equal(0))
+				})
+
+				It("returns ErrBindingAlreadyExists if the ACLs differ", func() {
+					fakeAivenClient.ListKafkaACLsReturns([]aiven.KafkaACL{
+						{ID: "acl1", Topic: "*", Permission: "readwrite", Username: testBindingID},
+					}, nil)
+
+					_, err := aivenProvider.Bind(bindCtx, bindData, true)
+					Expect(err).To(Equal(apiresponses.ErrBindingAlreadyExists))
+					Expect(fakeAivenClient.CreateServiceUserCallCount()).To(Equal(0))
+				})
+
+				It("compares against the default ACLs when none are requested", func() {
+					bindData.Details.RawParameters = nil
+					fakeAivenClient.ListKafkaACLsReturns([]aiven.KafkaACL{
+						{ID: "acl1", Topic: "*", Permission: "readwrite", Username: testBindingID},
+					}, nil)
+
+					binding, err := aivenProvider.Bind(bindCtx, bindData, true)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(binding.AlreadyExists).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the user already exists", func() {
+			BeforeEach(func() {
+				fakeAivenClient.GetServiceUserReturns(&aiven.User{
+					Username: testBindingID,
+					Password: stubPassword,
+				}, nil)
+			})
+
+			It("returns the existing credentials without creating the user again", func() {
+				binding, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeAivenClient.CreateServiceUserCallCount()).To(Equal(0))
+
+				_, getServiceUserArgs := fakeAivenClient.GetServiceUserArgsForCall(0)
+				Expect(getServiceUserArgs).To(Equal(&aiven.GetServiceUserInput{
+					ServiceName: "env-" + strings.ToLower(testInstanceID),
+					Username:    testBindingID,
+				}))
+
+				Expect(binding.AlreadyExists).To(BeTrue())
+				Expect(binding.IsAsync).To(BeFalse())
+				credentials := binding.Credentials.(provider.Credentials)
+				Expect(credentials.Username).To(Equal(testBindingID))
+				Expect(credentials.Password).To(Equal(stubPassword))
+				Expect(credentials.Hostname).To(Equal(testESHost))
+			})
+
+			It("errors if the user cannot be checked", func() {
+				fakeAivenClient.GetServiceUserReturns(nil, errors.New("some-error"))
+
+				_, err := aivenProvider.Bind(bindCtx, bindData, true)
+				Expect(err).To(MatchError("some-error"))
+				Expect(fakeAivenClient.CreateServiceUserCallCount()).To(Equal(0))
+			})
+		})
+
+		Describe("polling ES until the credentials work", func() {
+			var (
+				unauthorizedResponse http.HandlerFunc
+			)
 
 			BeforeEach(func() {
 				unauthorizedResponse = ghttp.CombineHandlers(
@@ -498,31 +1531,237 @@ var _ = Describe("Provider", func() {
 
 			It("retries calling the ES server if it gets a 401 initially", func() {
 				testESServer.AppendHandlers(versionResponse)
-				_, err := aivenProvider.Bind(bindCtx, bindData)
+				_, err := aivenProvider.Bind(bindCtx, bindData, false)
 				Expect(err).ToNot(HaveOccurred())
 
-				Expect(fakeAivenClient.DeleteServiceUserCallCount()).To(Equal(0))
-			})
+				Expect(fakeAivenClient.DeleteServiceUserCallCount()).To(Equal(0))
+			})
+
+			It("gives up polling and returns anyway after a timeout", func() {
+				testESServer.AppendHandlers(unauthorizedResponse)
+
+				ctx, cancel := context.WithTimeout(bindCtx, 900*time.Millisecond)
+				defer cancel()
+
+				_, err := aivenProvider.Bind(ctx, bindData, false)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns any other errors encountered while polling", func() {
+				testESServer.AppendHandlers(unauthorizedResponse)
+
+				ctx, cancel := context.WithCancel(bindCtx)
+				cancel() // cancelling to ensure a non-timeout error is triggered
+
+				_, err := aivenProvider.Bind(ctx, bindData, false)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("GetBinding", func() {
+		var getBindingData provider.GetBindingData
+
+		BeforeEach(func() {
+			getBindingData = provider.GetBindingData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				BindingID:  "D26EA3FB-AA78-451C-9ED0-233935ED388F",
+			}
+			fakeAivenClient.GetServiceUserReturns(&aiven.User{
+				Username: getBindingData.BindingID,
+				Password: "superdupersecret",
+			}, nil)
+			fakeAivenClient.GetServiceReturns(&aiven.Service{
+				ServiceUriParams: aiven.ServiceUriParams{
+					Host: "example.com",
+					Port: "23362",
+				},
+				ServiceType: "opensearch",
+			}, nil)
+		})
+
+		It("rebuilds the credentials of the existing service user", func() {
+			spec, err := aivenProvider.GetBinding(context.Background(), getBindingData)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, getServiceUserArgs := fakeAivenClient.GetServiceUserArgsForCall(0)
+			Expect(getServiceUserArgs).To(Equal(&aiven.GetServiceUserInput{
+				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+				Username:    getBindingData.BindingID,
+			}))
+
+			expectedCreds, err := provider.BuildCredentials(
+				"opensearch", getBindingData.BindingID, "superdupersecret", "example.com", "23362",
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec).To(Equal(domain.GetBindingSpec{Credentials: expectedCreds}))
+		})
+
+		It("includes the project CA for pg services", func() {
+			fakeAivenClient.GetServiceReturns(&aiven.Service{
+				ServiceUriParams: aiven.ServiceUriParams{
+					Host: "example.com",
+					Port: "23362",
+				},
+				ServiceType: "pg",
+			}, nil)
+			fakeAivenClient.GetProjectCAReturns("project-ca", nil)
+
+			spec, err := aivenProvider.GetBinding(context.Background(), getBindingData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeAivenClient.GetProjectCACallCount()).To(Equal(1))
+			Expect(spec.Credentials.(provider.Credentials).CACertificate).To(Equal("project-ca"))
+		})
+
+		It("includes the client certificate, key and project CA for kafka services", func() {
+			fakeAivenClient.GetServiceUserReturns(&aiven.User{
+				Username:   getBindingData.BindingID,
+				Password:   "superdupersecret",
+				AccessCert: "client-cert",
+				AccessKey:  "client-key",
+			}, nil)
+			fakeAivenClient.GetServiceReturns(&aiven.Service{
+				ServiceUriParams: aiven.ServiceUriParams{
+					Host: "example.com",
+					Port: "23362",
+				},
+				ServiceType: "kafka",
+			}, nil)
+			fakeAivenClient.GetProjectCAReturns("project-ca", nil)
+
+			spec, err := aivenProvider.GetBinding(context.Background(), getBindingData)
+			Expect(err).ToNot(HaveOccurred())
+
+			credentials := spec.Credentials.(provider.Credentials)
+			Expect(credentials.BootstrapServers).To(Equal("example.com:23362"))
+			Expect(credentials.ClientCertificate).To(Equal("client-cert"))
+			Expect(credentials.ClientKey).To(Equal("client-key"))
+			Expect(credentials.CACertificate).To(Equal("project-ca"))
+		})
+
+		It("includes the project CA for mysql services", func() {
+			fakeAivenClient.GetServiceReturns(&aiven.Service{
+				ServiceUriParams: aiven.ServiceUriParams{
+					Host: "example.com",
+					Port: "23362",
+				},
+				ServiceType: "mysql",
+			}, nil)
+			fakeAivenClient.GetProjectCAReturns("project-ca", nil)
+
+			spec, err := aivenProvider.GetBinding(context.Background(), getBindingData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Credentials.(provider.Credentials).URI).To(HavePrefix("mysql://"))
+			Expect(spec.Credentials.(provider.Credentials).CACertificate).To(Equal("project-ca"))
+		})
+
+		It("returns ErrBindingNotFound if the service user does not exist", func() {
+			fakeAivenClient.GetServiceUserReturns(nil, aiven.ErrInstanceUserDoesNotExist)
+
+			_, err := aivenProvider.GetBinding(context.Background(), getBindingData)
+			Expect(err).To(Equal(apiresponses.ErrBindingNotFound))
+		})
+
+		It("errors if the client fails to get the service", func() {
+			fakeAivenClient.GetServiceReturns(nil, errors.New("some-error"))
+
+			_, err := aivenProvider.GetBinding(context.Background(), getBindingData)
+			Expect(err).To(MatchError("some-error"))
+		})
+	})
+
+	Describe("LastBindingOperation", func() {
+		const (
+			testBindingID = "D26EA3FB-AA78-451C-9ED0-233935ED388F"
+			stubPassword  = "superdupersecret"
+		)
+		var (
+			testESServer             *ghttp.Server
+			lastBindingOperationData provider.LastBindingOperationData
+		)
+
+		BeforeEach(func() {
+			testESServer = ghttp.NewTLSServer()
+			http.DefaultClient = testESServer.HTTPTestServer.Client()
+
+			esURL, err := url.Parse(testESServer.URL())
+			Expect(err).NotTo(HaveOccurred())
+			parts := strings.SplitN(esURL.Host, ":", 2)
+			Expect(parts).To(HaveLen(2))
+
+			fakeAivenClient.GetServiceUserReturns(&aiven.User{
+				Username: testBindingID,
+				Password: stubPassword,
+			}, nil)
+			fakeAivenClient.GetServiceReturns(&aiven.Service{
+				ServiceUriParams: aiven.ServiceUriParams{
+					Host: parts[0],
+					Port: parts[1],
+				},
+				ServiceType: "opensearch",
+			}, nil)
+
+			lastBindingOperationData = provider.LastBindingOperationData{
+				InstanceID:    "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				BindingID:     testBindingID,
+				OperationData: provider.NewOperation(provider.BindOperation, nil).Encode(),
+			}
+		})
+
+		AfterEach(func() {
+			testESServer.Close()
+		})
+
+		It("succeeds once the credentials work", func() {
+			testESServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/"),
+				ghttp.VerifyBasicAuth(testBindingID, stubPassword),
+				ghttp.RespondWith(200, `{"version":{"number":"1.2.3"}}`),
+			))
+
+			state, description, err := aivenProvider.LastBindingOperation(context.Background(), lastBindingOperationData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state).To(Equal(domain.Succeeded))
+			Expect(description).To(Equal("Binding succeeded"))
+		})
+
+		It("is in progress while the credentials are rejected", func() {
+			testESServer.AppendHandlers(ghttp.RespondWith(401, `{"error":"unauthorized"}`))
 
-			It("gives up polling and returns anyway after a timeout", func() {
-				testESServer.AppendHandlers(unauthorizedResponse)
+			state, description, err := aivenProvider.LastBindingOperation(context.Background(), lastBindingOperationData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state).To(Equal(domain.InProgress))
+			Expect(description).To(Equal("Waiting for the credentials to become available"))
+		})
 
-				ctx, cancel := context.WithTimeout(bindCtx, 900*time.Millisecond)
-				defer cancel()
+		It("fails if the credentials still do not work after the timeout", func() {
+			operation := provider.NewOperation(provider.BindOperation, nil)
+			operation.StartedAt = time.Now().Add(-31 * time.Second)
+			lastBindingOperationData.OperationData = operation.Encode()
+			testESServer.AppendHandlers(ghttp.RespondWith(401, `{"error":"unauthorized"}`))
 
-				_, err := aivenProvider.Bind(ctx, bindData)
-				Expect(err).NotTo(HaveOccurred())
-			})
+			state, description, err := aivenProvider.LastBindingOperation(context.Background(), lastBindingOperationData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state).To(Equal(domain.Failed))
+			Expect(description).To(Equal(
+				"Binding failed: the broker could not connect with the new credentials within 30s. " +
+					"Check that the IP filter of the service allows the broker to connect, then try binding again.",
+			))
+		})
 
-			It("returns any other errors encountered while polling", func() {
-				testESServer.AppendHandlers(unauthorizedResponse)
+		It("fails if the service user does not exist", func() {
+			fakeAivenClient.GetServiceUserReturns(nil, aiven.ErrInstanceUserDoesNotExist)
 
-				ctx, cancel := context.WithCancel(bindCtx)
-				cancel() // cancelling to ensure a non-timeout error is triggered
+			state, _, err := aivenProvider.LastBindingOperation(context.Background(), lastBindingOperationData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(state).To(Equal(domain.Failed))
+		})
 
-				_, err := aivenProvider.Bind(ctx, bindData)
-				Expect(err).To(HaveOccurred())
-			})
+		It("errors if the client fails to get the service", func() {
+			fakeAivenClient.GetServiceReturns(nil, errors.New("some-error"))
+
+			_, _, err := aivenProvider.LastBindingOperation(context.Background(), lastBindingOperationData)
+			Expect(err).To(MatchError("some-error"))
 		})
 	})
 
@@ -531,6 +1770,7 @@ var _ = Describe("Provider", func() {
 			unbindData := provider.UnbindData{
 				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
 				BindingID:  "D26EA3FB-AA78-451C-9ED0-233935ED388F",
+				Details:    domain.UnbindDetails{ServiceID: "uuid-1"},
 			}
 			err := aivenProvider.Unbind(context.Background(), unbindData)
 			Expect(err).ToNot(HaveOccurred())
@@ -541,13 +1781,15 @@ var _ = Describe("Provider", func() {
 			}
 
 			Expect(fakeAivenClient.DeleteServiceUserCallCount()).To(Equal(1))
-			Expect(fakeAivenClient.DeleteServiceUserArgsForCall(0)).To(Equal(expectedDeleteServiceUserParameters))
+			_, deleteServiceUserArgs := fakeAivenClient.DeleteServiceUserArgsForCall(0)
+			Expect(deleteServiceUserArgs).To(Equal(expectedDeleteServiceUserParameters))
 		})
 
 		It("returns ErrBindingDoesNotExist if client returns ErrInstanceUserDoesNotExist", func() {
 			unbindData := provider.UnbindData{
 				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
 				BindingID:  "D26EA3FB-AA78-451C-9ED0-233935ED388F",
+				Details:    domain.UnbindDetails{ServiceID: "uuid-1"},
 			}
 			fakeAivenClient.DeleteServiceUserReturnsOnCall(0, "", aiven.ErrInstanceUserDoesNotExist)
 
@@ -555,10 +1797,41 @@ var _ = Describe("Provider", func() {
 			Expect(err).To(Equal(apiresponses.ErrBindingDoesNotExist))
 		})
 
+		It("removes the ACLs of the user before deleting kafka users", func() {
+			config.Catalog.Services = append(config.Catalog.Services, provider.Service{
+				Service: domain.Service{ID: "kafka-uuid", Name: "kafka"},
+			})
+			unbindData := provider.UnbindData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				BindingID:  "D26EA3FB-AA78-451C-9ED0-233935ED388F",
+				Details:    domain.UnbindDetails{ServiceID: "kafka-uuid"},
+			}
+			fakeAivenClient.ListKafkaACLsReturns([]aiven.KafkaACL{
+				{ID: "default", Permission: "admin", Topic: "*", Username: "avnadmin"},
+				{ID: "acl1", Permission: "read", Topic: "orders", Username: unbindData.BindingID},
+				{ID: "acl2", Permission: "write", Topic: "events", Username: unbindData.BindingID},
+			}, nil)
+
+			err := aivenProvider.Unbind(context.Background(), unbindData)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, listKafkaACLsArgs := fakeAivenClient.ListKafkaACLsArgsForCall(0)
+			Expect(listKafkaACLsArgs).To(Equal(&aiven.ListKafkaACLsInput{
+				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+			}))
+			Expect(fakeAivenClient.DeleteKafkaACLCallCount()).To(Equal(2))
+			_, deleteKafkaACLArgs := fakeAivenClient.DeleteKafkaACLArgsForCall(0)
+			Expect(deleteKafkaACLArgs.ACLID).To(Equal("acl1"))
+			_, deleteKafkaACLArgs1 := fakeAivenClient.DeleteKafkaACLArgsForCall(1)
+			Expect(deleteKafkaACLArgs1.ACLID).To(Equal("acl2"))
+			Expect(fakeAivenClient.DeleteServiceUserCallCount()).To(Equal(1))
+		})
+
 		It("errors if the client returns an unexpected error", func() {
 			unbindData := provider.UnbindData{
 				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
 				BindingID:  "D26EA3FB-AA78-451C-9ED0-233935ED388F",
+				Details:    domain.UnbindDetails{ServiceID: "uuid-1"},
 			}
 			fakeAivenClient.DeleteServiceUserReturnsOnCall(0, "", errors.New("some-error"))
 
@@ -585,109 +1858,489 @@ var _ = Describe("Provider", func() {
 
 			userConfig := aiven.UserConfig{}
 			userConfig.OpenSearchVersion = "1"
-			userConfig.IPFilter = []string{"1.2.3.4", "5.6.7.8"}
+			userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4", "5.6.7.8")
+
+			expectedParameters := &aiven.UpdateServiceInput{
+				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+				Plan:        "startup-2",
+				UserConfig:  userConfig,
+			}
+			_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+			Expect(updateServiceArgs).To(Equal(expectedParameters))
+		})
+		It("returns the operation so that LastOperation can check the update was applied", func() {
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID:      "uuid-1",
+					PlanID:         "uuid-3",
+					PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
+				},
+			}
+			result, err := aivenProvider.Update(context.Background(), updateData, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			operation := provider.DecodeOperation(result.OperationData)
+			Expect(operation.Type).To(Equal(provider.UpdateOperation))
+			Expect(operation.PlanID).To(Equal("uuid-3"))
+			Expect(operation.PreviousPlanID).To(Equal("uuid-2"))
+			Expect(operation.AivenPlan).To(Equal("startup-2"))
+			Expect(operation.StartedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(operation.PreviousNodes).To(BeEmpty())
+			Expect(operation.UserConfigKeys).To(ContainElement("opensearch_version"))
+
+			_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+			Expect(operation.UserConfigApplied(updateServiceArgs.UserConfig)).To(BeTrue())
+		})
+
+		It("records the nodes which a change of Aiven plan will replace", func() {
+			fakeAivenClient.GetServiceReturns(&aiven.Service{
+				State: aiven.Running,
+				Plan:  "startup-1",
+				NodeStates: []aiven.NodeState{
+					{Name: "opensearch-1", State: aiven.NodeRunning},
+					{Name: "opensearch-2", State: aiven.NodeRunning},
+				},
+			}, nil)
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID:      "uuid-1",
+					PlanID:         "uuid-3",
+					PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
+				},
+			}
+			result, err := aivenProvider.Update(context.Background(), updateData, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			operation := provider.DecodeOperation(result.OperationData)
+			Expect(operation.PreviousNodes).To(Equal([]string{"opensearch-1", "opensearch-2"}))
+		})
+
+		It("does not record the nodes when the Aiven plan stays the same", func() {
+			fakeAivenClient.GetServiceReturns(&aiven.Service{
+				State: aiven.Running,
+				Plan:  "startup-2",
+				NodeStates: []aiven.NodeState{
+					{Name: "opensearch-1", State: aiven.NodeRunning},
+				},
+			}, nil)
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID: "uuid-1",
+					PlanID:    "uuid-3",
+				},
+			}
+			result, err := aivenProvider.Update(context.Background(), updateData, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(provider.DecodeOperation(result.OperationData).PreviousNodes).To(BeEmpty())
+		})
+		It("should enable updating IP auth lists", func() {
+			os.Setenv("IP_WHITELIST", "1.2.3.4,5.6.7.8")
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID:     "uuid-1",
+					PlanID:        "uuid-3",
+					RawParameters: json.RawMessage(`{"ip_filter": "9.10.11.12"}`),
+				},
+			}
+			_, err := aivenProvider.Update(context.Background(), updateData, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
+
+			userConfig := aiven.UserConfig{}
+			userConfig.OpenSearchVersion = "1"
+			userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4", "5.6.7.8", "9.10.11.12")
 
 			expectedParameters := &aiven.UpdateServiceInput{
 				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
 				Plan:        "startup-2",
 				UserConfig:  userConfig,
 			}
-			Expect(fakeAivenClient.UpdateServiceArgsForCall(0)).To(Equal(expectedParameters))
+			_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+			Expect(updateServiceArgs).To(Equal(expectedParameters))
+		})
+
+		It("should pass the Redis settings of the new plan for redis services", func() {
+			config.Catalog.Services[0].Name = "redis"
+			config.Catalog.Services[0].Plans[1].RedisMaxmemoryPolicy = "volatile-lru"
+			config.Catalog.Services[0].Plans[1].RedisPersistence = "rdb"
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID: "uuid-1",
+					PlanID:    "uuid-3",
+				},
+			}
+			_, err := aivenProvider.Update(context.Background(), updateData, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+			userConfig := updateServiceArgs.UserConfig
+			Expect(userConfig.RedisMaxmemoryPolicy).To(Equal("volatile-lru"))
+			Expect(userConfig.RedisPersistence).To(Equal("rdb"))
+		})
+
+		It("should update the service tags on plan change", func() {
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID:      "uuid-1",
+					PlanID:         "uuid-3",
+					PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
+				},
+			}
+			originalTags := aiven.ServiceTags{
+				DeployEnv:          config.DeployEnv,
+				ServiceID:          updateData.InstanceID,
+				PlanID:             updateData.Details.PreviousValues.PlanID,
+				OrganizationID:     "some-org",
+				SpaceID:            "some-space",
+				BrokerName:         config.BrokerName,
+				RestoredFromBackup: "false",
+			}
+			fakeAivenClient.GetServiceTagsReturns(&originalTags, nil)
+			_, err := aivenProvider.Update(context.Background(), updateData, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
+			Expect(fakeAivenClient.UpdateServiceTagsCallCount()).To(Equal(1))
+			expectedTags := originalTags
+			expectedTags.PlanID = updateData.Details.PlanID
+			_, updateServiceTagsArgs := fakeAivenClient.UpdateServiceTagsArgsForCall(0)
+			Expect(updateServiceTagsArgs.Tags).To(Equal(expectedTags))
+		})
+
+		It("should return an error if the client returns error", func() {
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID:      "uuid-1",
+					PlanID:         "uuid-3",
+					PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
+					RawParameters:  nil,
+				},
+			}
+			fakeAivenClient.UpdateServiceReturnsOnCall(0, "", errors.New("some bad thing"))
+
+			_, err := aivenProvider.Update(context.Background(), updateData, true)
+
+			Expect(err).To(HaveOccurred())
+			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
+		})
+
+		It("should returns StatusUnprocessableEntity (422) if the client returns invalid update error", func() {
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID:      "uuid-1",
+					PlanID:         "uuid-3",
+					PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
+					RawParameters:  nil,
+				},
+			}
+			fakeAivenClient.UpdateServiceReturnsOnCall(0, "", aiven.ErrInvalidUpdate{Message: "not-valid"})
+
+			_, err := aivenProvider.Update(context.Background(), updateData, true)
+
+			failureResponse := &apiresponses.FailureResponse{}
+			Expect(errors.As(err, &failureResponse)).To(BeTrue())
+			Expect(failureResponse.ValidatedStatusCode(nil)).To(Equal(http.StatusUnprocessableEntity))
+			Expect(failureResponse.ErrorResponse()).To(Equal(apiresponses.ErrorResponse{
+				Error:       "PlanChangeNotSupported",
+				Description: "Aiven does not support this plan change",
+			}))
+			Expect(err).To(MatchError(aiven.ErrInvalidUpdate{Message: "not-valid"}))
+			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
+		})
+
+		Context("when merging with the current user config", func() {
+			var (
+				updateData    provider.UpdateData
+				currentConfig aiven.UserConfig
+			)
+
+			BeforeEach(func() {
+				os.Unsetenv("IP_WHITELIST")
+				updateData = provider.UpdateData{
+					InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+					Details: domain.UpdateDetails{
+						ServiceID:      "uuid-1",
+						PlanID:         "uuid-3",
+						PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
+					},
+				}
+				currentConfig = aiven.UserConfig{}
+				currentConfig.IPFilter = aiven.NewIPFilter("1.2.3.4/32", "10.0.0.0/8")
+				currentConfig.OpenSearchVersion = "1"
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, UserConfig: currentConfig}, nil)
+			})
+
+			sentUserConfig := func() aiven.UserConfig {
+				Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
+				_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+				return updateServiceArgs.UserConfig
+			}
+
+			It("fetches the current user config of the service", func() {
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, getServiceArgs := fakeAivenClient.GetServiceArgsForCall(0)
+				Expect(getServiceArgs).To(Equal(&aiven.GetServiceInput{
+					ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+				}))
+			})
+
+			It("keeps the current IP filter when a plan change does not set one", func() {
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.NewIPFilter("1.2.3.4", "10.0.0.0/8")))
+			})
+
+			It("does not carry over the IP_WHITELIST or the mandatory ranges of the previous plan", func() {
+				os.Setenv("IP_WHITELIST", "198.51.100.7")
+				defer os.Unsetenv("IP_WHITELIST")
+				config.Catalog.Services[0].Plans[0].IPFilterPolicy.MandatoryRanges = []string{"192.0.2.0/24"}
+				config.Catalog.Services[0].Plans[1].IPFilterPolicy.MandatoryRanges = []string{"203.0.113.0/24"}
+				currentConfig.IPFilter = aiven.NewIPFilter("198.51.100.7/32", "192.0.2.0/24", "1.2.3.4")
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, UserConfig: currentConfig}, nil)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.NewIPFilter("198.51.100.7", "203.0.113.0/24", "1.2.3.4")))
+			})
+
+			It("changes the plan of a service which still has Aiven's default IP filter", func() {
+				config.IPFilterPolicy = provider.IPFilterPolicy{
+					ForbiddenRanges:     []string{"169.254.0.0/16"},
+					MinIPv4PrefixLength: 24,
+				}
+				currentConfig.IPFilter = aiven.NewIPFilter(provider.AivenDefaultIPFilter)
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, UserConfig: currentConfig}, nil)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.NewIPFilter(provider.AivenDefaultIPFilter)))
+			})
+
+			It("checks the IP filter sent with the plan change against the policy", func() {
+				config.IPFilterPolicy = provider.IPFilterPolicy{MinIPv4PrefixLength: 24}
+				currentConfig.IPFilter = aiven.NewIPFilter(provider.AivenDefaultIPFilter)
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, UserConfig: currentConfig}, nil)
+				updateData.Details.RawParameters = json.RawMessage(`{"ip_filter": "10.0.0.0/8"}`)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).To(MatchError("IP filter entry '10.0.0.0/8' is not allowed because it is wider than /24"))
+				Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
+			})
+
+			It("does not check the mandatory ranges of the previous plan against the forbidden ranges", func() {
+				config.Catalog.Services[0].Plans[0].IPFilterPolicy.MandatoryRanges = []string{"10.0.0.0/8"}
+				config.IPFilterPolicy.ForbiddenRanges = []string{"10.0.0.0/8"}
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.NewIPFilter("1.2.3.4")))
+			})
+
+			It("keeps the descriptions of the entries in the current IP filter", func() {
+				currentConfig.IPFilter = aiven.IPFilter{
+					{Network: "1.2.3.4/32", Description: "office"},
+					{Network: "10.0.0.0/8"},
+				}
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, UserConfig: currentConfig}, nil)
+				updateData.Details.RawParameters = json.RawMessage(`{"ip_filter": "1.2.3.4,9.10.11.12"}`)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.IPFilter{
+					{Network: "1.2.3.4", Description: "office"},
+					{Network: "9.10.11.12"},
+				}))
+			})
+
+			It("replaces the IP filter when one is given", func() {
+				updateData.Details.RawParameters = json.RawMessage(`{"ip_filter": "9.10.11.12"}`)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.NewIPFilter("9.10.11.12")))
+			})
+
+			It("removes the user's entries but keeps the mandatory ranges when the IP filter is empty", func() {
+				config.IPFilterPolicy.MandatoryRanges = []string{"192.0.2.0/24"}
+				currentConfig.IPFilter = aiven.NewIPFilter("192.0.2.0/24", "1.2.3.4")
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, UserConfig: currentConfig}, nil)
+				updateData.Details.RawParameters = json.RawMessage(`{"ip_filter": ""}`)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.NewIPFilter("192.0.2.0/24")))
+			})
+
+			It("restores Aiven's default when every entry is removed", func() {
+				updateData.Details.RawParameters = json.RawMessage(`{"ip_filter": ""}`)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.NewIPFilter(provider.AivenDefaultIPFilter)))
+			})
+
+			It("keeps settings which are not being changed", func() {
+				currentConfig.RedisMaxmemoryPolicy = "allkeys-lru"
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, UserConfig: currentConfig}, nil)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().RedisMaxmemoryPolicy).To(Equal("allkeys-lru"))
+			})
+
+			It("applies the settings of the new plan over the current settings", func() {
+				config.Catalog.Services[0].Plans[1].OpenSearchVersion = "2"
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().OpenSearchVersion).To(Equal("2"))
+			})
+
+			It("does not send the settings which forked the service", func() {
+				currentConfig.ForkProject = "project"
+				currentConfig.BackupServiceName = "env-source"
+				currentConfig.BackupName = "backup-1"
+				fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, UserConfig: currentConfig}, nil)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				userConfig := sentUserConfig()
+				Expect(userConfig.ForkProject).To(BeEmpty())
+				Expect(userConfig.BackupServiceName).To(BeEmpty())
+				Expect(userConfig.BackupName).To(BeEmpty())
+			})
+
+			It("does not check the mandatory ranges against the forbidden ranges", func() {
+				config.IPFilterPolicy = provider.IPFilterPolicy{
+					MandatoryRanges: []string{"10.0.0.0/8"},
+					ForbiddenRanges: []string{"10.0.0.0/8"},
+				}
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sentUserConfig().IPFilter).To(Equal(aiven.NewIPFilter("10.0.0.0/8", "1.2.3.4")))
+			})
+
+			It("returns ErrInstanceDoesNotExist if the service does not exist", func() {
+				fakeAivenClient.GetServiceReturns(nil, aiven.ErrInstanceDoesNotExist)
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).To(Equal(apiresponses.ErrInstanceDoesNotExist))
+				Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
+			})
+
+			It("returns ErrInstanceDoesNotExist if Aiven responds with a 404", func() {
+				fakeAivenClient.GetServiceReturns(nil, &aiven.APIError{StatusCode: http.StatusNotFound})
+
+				_, err := aivenProvider.Update(context.Background(), updateData, true)
+				Expect(err).To(Equal(apiresponses.ErrInstanceDoesNotExist))
+				Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
+			})
+		})
+
+		It("changes the maintenance window when one is given", func() {
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID:     "uuid-1",
+					PlanID:        "uuid-3",
+					RawParameters: json.RawMessage(`{"maintenance_window": {"dow": "friday", "time": "23:00"}}`),
+				},
+			}
+
+			_, err := aivenProvider.Update(context.Background(), updateData, true)
+			Expect(err).ToNot(HaveOccurred())
+			_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+			Expect(updateServiceArgs.Maintenance).To(Equal(&aiven.Maintenance{DOW: "friday", Time: "23:00:00"}))
 		})
-		It("should enable updating IP auth lists", func() {
-			os.Setenv("IP_WHITELIST", "1.2.3.4,5.6.7.8")
+
+		It("keeps the maintenance window when none is given", func() {
 			updateData := provider.UpdateData{
 				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
 				Details: domain.UpdateDetails{
-					ServiceID:     "uuid-1",
-					PlanID:        "uuid-3",
-					RawParameters: json.RawMessage(`{"ip_filter": "9.10.11.12"}`),
+					ServiceID: "uuid-1",
+					PlanID:    "uuid-3",
 				},
 			}
+
 			_, err := aivenProvider.Update(context.Background(), updateData, true)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
-
-			userConfig := aiven.UserConfig{}
-			userConfig.OpenSearchVersion = "1"
-			userConfig.IPFilter = []string{"1.2.3.4", "5.6.7.8", "9.10.11.12"}
+			_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+			Expect(updateServiceArgs.Maintenance).To(BeNil())
+		})
 
-			expectedParameters := &aiven.UpdateServiceInput{
-				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
-				Plan:        "startup-2",
-				UserConfig:  userConfig,
+		It("rejects an invalid maintenance window", func() {
+			updateData := provider.UpdateData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+				Details: domain.UpdateDetails{
+					ServiceID:     "uuid-1",
+					PlanID:        "uuid-3",
+					RawParameters: json.RawMessage(`{"maintenance_window": {"dow": "friday", "time": "late"}}`),
+				},
 			}
-			Expect(fakeAivenClient.UpdateServiceArgsForCall(0)).To(Equal(expectedParameters))
+
+			_, err := aivenProvider.Update(context.Background(), updateData, true)
+			Expect(err).To(MatchError("Invalid maintenance window time: 'late'. It must be a time in UTC, such as '03:00'"))
+			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
 		})
 
-		It("should update the service tags on plan change", func() {
+		It("changes termination protection when it is given", func() {
 			updateData := provider.UpdateData{
 				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
 				Details: domain.UpdateDetails{
-					ServiceID:      "uuid-1",
-					PlanID:         "uuid-3",
-					PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
+					ServiceID:     "uuid-1",
+					PlanID:        "uuid-3",
+					RawParameters: json.RawMessage(`{"termination_protection": false}`),
 				},
 			}
-			originalTags := aiven.ServiceTags{
-				DeployEnv:          config.DeployEnv,
-				ServiceID:          updateData.InstanceID,
-				PlanID:             updateData.Details.PreviousValues.PlanID,
-				OrganizationID:     "some-org",
-				SpaceID:            "some-space",
-				BrokerName:         config.BrokerName,
-				RestoredFromBackup: "false",
-			}
-			fakeAivenClient.GetServiceTagsReturns(&originalTags, nil)
+
 			_, err := aivenProvider.Update(context.Background(), updateData, true)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
-			Expect(fakeAivenClient.UpdateServiceTagsCallCount()).To(Equal(1))
-			expectedTags := originalTags
-			expectedTags.PlanID = updateData.Details.PlanID
-			Expect(fakeAivenClient.UpdateServiceTagsArgsForCall(0).Tags).To(Equal(expectedTags))
+			_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+			Expect(updateServiceArgs.TerminationProtection).ToNot(BeNil())
+			Expect(*updateServiceArgs.TerminationProtection).To(BeFalse())
 		})
 
-		It("should return an error if the client returns error", func() {
+		It("keeps termination protection when it is not given", func() {
+			config.Catalog.Services[0].Plans[1].TerminationProtection = true
 			updateData := provider.UpdateData{
 				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
 				Details: domain.UpdateDetails{
-					ServiceID:      "uuid-1",
-					PlanID:         "uuid-3",
-					PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
-					RawParameters:  nil,
+					ServiceID: "uuid-1",
+					PlanID:    "uuid-3",
 				},
 			}
-			fakeAivenClient.UpdateServiceReturnsOnCall(0, "", errors.New("some bad thing"))
 
 			_, err := aivenProvider.Update(context.Background(), updateData, true)
-
-			Expect(err).To(HaveOccurred())
-			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
+			Expect(err).ToNot(HaveOccurred())
+			_, updateServiceArgs := fakeAivenClient.UpdateServiceArgsForCall(0)
+			Expect(updateServiceArgs.TerminationProtection).To(BeNil())
 		})
 
-		It("should returns StatusUnprocessableEntity (422) if the client returns invalid update error", func() {
+		It("rejects IP filters which break the IP filter policy", func() {
+			config.IPFilterPolicy.MinIPv4PrefixLength = 24
 			updateData := provider.UpdateData{
 				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
 				Details: domain.UpdateDetails{
-					ServiceID:      "uuid-1",
-					PlanID:         "uuid-3",
-					PreviousValues: domain.PreviousValues{PlanID: "uuid-2"},
-					RawParameters:  nil,
+					ServiceID:     "uuid-1",
+					PlanID:        "uuid-3",
+					RawParameters: json.RawMessage(`{"ip_filter": "0.0.0.0/0"}`),
 				},
 			}
-			fakeAivenClient.UpdateServiceReturnsOnCall(0, "", aiven.ErrInvalidUpdate{"not-valid"})
 
 			_, err := aivenProvider.Update(context.Background(), updateData, true)
-
-			expectedErr := apiresponses.NewFailureResponseBuilder(
-				aiven.ErrInvalidUpdate{"not-valid"},
-				http.StatusUnprocessableEntity,
-				"plan-change-not-supported",
-			).WithErrorKey("PlanChangeNotSupported").Build()
-
-			Expect(err).To(MatchError(expectedErr))
-			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(1))
+			Expect(err).To(MatchError("IP filter entry '0.0.0.0/0' is not allowed because it is wider than /24"))
+			Expect(fakeAivenClient.UpdateServiceCallCount()).To(Equal(0))
 		})
 	})
 
@@ -709,37 +2362,264 @@ var _ = Describe("Provider", func() {
 
 			Expect(err).ToNot(HaveOccurred())
 
-			Expect(fakeAivenClient.GetServiceArgsForCall(0)).To(Equal(expectedGetServiceStatusParameters))
+			_, getServiceArgs := fakeAivenClient.GetServiceArgsForCall(0)
+			Expect(getServiceArgs).To(Equal(expectedGetServiceStatusParameters))
 			Expect(actualLastOperationState).To(Equal(domain.Succeeded))
 			Expect(description).To(Equal("Last operation succeeded"))
 		})
 
-		// After an update operation the API immediately reports the state as 'RUNNING', which
-		// would cause the broker to think it has completed updating. It takes a few seconds for
-		// it to report as 'REBUILDING'. We thought we could use the `plan` data from the API to check
-		// for when it is running with the new plan, but unfortunately the API shows the new plan
-		// immediately (even when it says it is 'RUNNING').
-		Context("when the state is RUNNING, but the service has only just been updated", func() {
-			It("should report it 'in progress' for up to 60 seconds after the updated time", func() {
-				expectedGetServiceParameters := &aiven.GetServiceInput{
-					ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+		Context("when polling an update", func() {
+			var (
+				lastOperationData provider.LastOperationData
+				operation         provider.Operation
+				userConfig        aiven.UserConfig
+			)
+
+			BeforeEach(func() {
+				plan, err := config.FindPlan("uuid-1", "uuid-3")
+				Expect(err).ToNot(HaveOccurred())
+
+				userConfig = aiven.UserConfig{}
+				userConfig.OpenSearchVersion = "1"
+				userConfig.IPFilter = aiven.NewIPFilter("1.2.3.4", "10.0.0.0/8")
+
+				operation = provider.NewOperation(provider.UpdateOperation, plan)
+				operation.PreviousPlanID = "uuid-2"
+				operation.PreviousNodes = []string{"opensearch-1", "opensearch-2"}
+				operation.StartedAt = time.Now().Add(-5 * time.Minute)
+				operation, err = operation.WithUserConfig(userConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				lastOperationData = provider.LastOperationData{
+					InstanceID:    "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+					OperationData: operation.Encode(),
 				}
+			})
 
-				lastOperationData := provider.LastOperationData{
-					InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			It("succeeds once the service is running with the new plan and config", func() {
+				// Aiven returns more of the user config than the broker sets
+				appliedConfig := userConfig
+				appliedConfig.IPFilter = aiven.NewIPFilter("10.0.0.0/8", "1.2.3.4/32")
+				appliedConfig.PostgreSQLVersion = "15"
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+					State: aiven.Running, Plan: "startup-2", UserConfig: appliedConfig,
+					NodeStates: []aiven.NodeState{
+						{Name: "opensearch-3", State: aiven.NodeRunning},
+						{Name: "opensearch-4", State: aiven.NodeRunning},
+					},
+				}, nil)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Succeeded))
+				Expect(description).To(Equal("Last operation succeeded"))
+			})
+
+			It("only compares the networks of the IP filter entries", func() {
+				appliedConfig := userConfig
+				appliedConfig.IPFilter = aiven.IPFilter{
+					{Network: "10.0.0.0/8"},
+					{Network: "1.2.3.4/32", Description: "office"},
+				}
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+					State: aiven.Running, Plan: "startup-2", UserConfig: appliedConfig,
+					NodeStates: []aiven.NodeState{
+						{Name: "opensearch-3", State: aiven.NodeRunning},
+					},
+				}, nil)
+
+				state, _, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Succeeded))
+			})
+
+			// The API reports the new plan and a state of 'RUNNING' as soon as
+			// the change is requested, before it starts building new nodes
+			It("stays in progress while the service only has the old nodes", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+					State: aiven.Running, Plan: "startup-2", UserConfig: userConfig,
+					NodeStates: []aiven.NodeState{
+						{Name: "opensearch-1", State: aiven.NodeRunning},
+						{Name: "opensearch-2", State: aiven.NodeRunning},
+					},
+				}, nil)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.InProgress))
+				Expect(description).To(Equal("Waiting for the new plan to be applied"))
+			})
+
+			It("stays in progress until the old nodes have left", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+					State: aiven.Running, Plan: "startup-2", UserConfig: userConfig,
+					NodeStates: []aiven.NodeState{
+						{Name: "opensearch-1", State: aiven.NodeLeaving},
+						{Name: "opensearch-3", State: aiven.NodeRunning},
+					},
+				}, nil)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.InProgress))
+				Expect(description).To(Equal("Waiting for the new plan to be applied"))
+			})
+
+			It("stays in progress until the new nodes are running", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+					State: aiven.Running, Plan: "startup-2", UserConfig: userConfig,
+					NodeStates: []aiven.NodeState{
+						{Name: "opensearch-3", State: aiven.NodeSyncingData},
+					},
+				}, nil)
+
+				state, _, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.InProgress))
+			})
+
+			It("does not wait for new nodes for updates which keep the same Aiven plan", func() {
+				operation.PreviousNodes = nil
+				lastOperationData.OperationData = operation.Encode()
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+					State: aiven.Running, Plan: "startup-2", UserConfig: userConfig,
+					NodeStates: []aiven.NodeState{
+						{Name: "opensearch-1", State: aiven.NodeRunning},
+					},
+				}, nil)
+
+				state, _, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Succeeded))
+			})
+
+			It("stays in progress while the service has the old config", func() {
+				oldConfig := userConfig
+				oldConfig.IPFilter = aiven.NewIPFilter("1.2.3.4")
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+					State: aiven.Running, Plan: "startup-2", UserConfig: oldConfig,
+					NodeStates: []aiven.NodeState{{Name: "opensearch-3", State: aiven.NodeRunning}},
+				}, nil)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.InProgress))
+				Expect(description).To(Equal("Waiting for the new configuration to be applied"))
+			})
+
+			It("fails the operation once it has exceeded the deadline", func() {
+				config.OperationDeadlineMinutes = 60
+				operation.StartedAt = time.Now().Add(-61 * time.Minute)
+				lastOperationData.OperationData = operation.Encode()
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
+					State: aiven.Rebuilding, Plan: "startup-2", UserConfig: userConfig,
+				}, nil)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Failed))
+				Expect(description).To(Equal("Last operation failed: not completed within 1h0m0s (Rebuilding)"))
+			})
+		})
+
+		Context("when polling a provision", func() {
+			var lastOperationData provider.LastOperationData
+
+			BeforeEach(func() {
+				lastOperationData = provider.LastOperationData{
+					InstanceID:    "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+					OperationData: provider.NewOperation(provider.ProvisionOperation, nil).Encode(),
 				}
+			})
+
+			It("fails if Aiven powered the service off while creating it", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.PowerOff}, nil)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Failed))
+				Expect(description).To(Equal("Last operation failed: service was powered off by Aiven while it was being created"))
+			})
+
+			It("fails if the service no longer exists", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.Missing}, aiven.ErrInstanceDoesNotExist)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Failed))
+				Expect(description).To(Equal("Last operation failed: service does not exist"))
+			})
 
-				thirtySecondsAgo := time.Now().Add(-1 * 30 * time.Second)
+			It("describes which node is lagging", func() {
 				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{
-					State: aiven.Running, UpdateTime: thirtySecondsAgo,
+					State: aiven.Rebuilding,
+					NodeStates: []aiven.NodeState{
+						{Name: "node-1", State: aiven.NodeRunning},
+						{Name: "node-2", State: aiven.NodeSettingUpVM},
+					},
 				}, nil)
-				actualLastOperationState, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
 
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.InProgress))
+				Expect(description).To(Equal("Rebuilding: 1 of 2 nodes running; node-2 is setting up vm"))
+			})
+		})
+
+		Context("when polling a deprovision", func() {
+			var lastOperationData provider.LastOperationData
+
+			BeforeEach(func() {
+				lastOperationData = provider.LastOperationData{
+					InstanceID:    "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+					OperationData: provider.NewOperation(provider.DeprovisionOperation, nil).Encode(),
+				}
+			})
+
+			It("succeeds once the service has been deleted", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, nil, aiven.ErrInstanceDoesNotExist)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Succeeded))
+				Expect(description).To(Equal("Service has been deleted"))
+			})
+
+			It("stays in progress while the service still exists", func() {
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.Running}, nil)
+
+				state, _, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
 				Expect(err).ToNot(HaveOccurred())
-				Expect(fakeAivenClient.GetServiceArgsForCall(0)).To(Equal(expectedGetServiceParameters))
+				Expect(state).To(Equal(domain.InProgress))
+			})
+
+			It("succeeds once a soft deleted service has been powered off", func() {
+				lastOperationData.OperationData = provider.NewOperation(provider.SoftDeleteOperation, nil).Encode()
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.PowerOff}, nil)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Succeeded))
+				Expect(description).To(Equal("Service has been powered off and will be deleted later"))
+			})
+
+			It("stays in progress while a soft deleted service is powering off", func() {
+				lastOperationData.OperationData = provider.NewOperation(provider.SoftDeleteOperation, nil).Encode()
+				fakeAivenClient.GetServiceReturnsOnCall(0, &aiven.Service{State: aiven.Running}, nil)
+
+				state, description, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.InProgress))
+				Expect(description).To(Equal("Powering off service"))
+			})
+
+			It("understands the operation data of earlier versions of the broker", func() {
+				lastOperationData.OperationData = "deprovisioning"
+				fakeAivenClient.GetServiceReturnsOnCall(0, nil, aiven.ErrInstanceDoesNotExist)
 
-				Expect(actualLastOperationState).To(Equal(domain.InProgress))
-				Expect(description).To(Equal("Preparing to apply update"))
+				state, _, err := aivenProvider.LastOperation(context.Background(), lastOperationData)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(Equal(domain.Succeeded))
 			})
 		})
 
@@ -758,6 +2638,178 @@ var _ = Describe("Provider", func() {
 		})
 	})
 
+	Describe("GetInstance", func() {
+		var getInstanceData provider.GetInstanceData
+
+		BeforeEach(func() {
+			getInstanceData = provider.GetInstanceData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			}
+		})
+
+		It("returns the service, plan and parameters of the instance", func() {
+			service := &aiven.Service{State: aiven.Running}
+			service.UserConfig.IPFilter = aiven.NewIPFilter("1.2.3.4", "5.6.7.8")
+			fakeAivenClient.GetServiceReturns(service, nil)
+			fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{
+				PlanID:             "uuid-3",
+				RestoredFromBackup: "false",
+			}, nil)
+
+			spec, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, getServiceArgs := fakeAivenClient.GetServiceArgsForCall(0)
+			Expect(getServiceArgs).To(Equal(&aiven.GetServiceInput{
+				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+			}))
+			_, getServiceTagsArgs := fakeAivenClient.GetServiceTagsArgsForCall(0)
+			Expect(getServiceTagsArgs).To(Equal(&aiven.GetServiceTagsInput{
+				ServiceName: "env-09e1993e-62e2-4040-adf2-4d3ec741efe6",
+			}))
+			Expect(spec).To(Equal(domain.GetInstanceDetailsSpec{
+				ServiceID: "uuid-1",
+				PlanID:    "uuid-3",
+				Parameters: provider.InstanceParameters{
+					UserIpFilter: "1.2.3.4,5.6.7.8",
+				},
+			}))
+		})
+
+		It("leaves the ranges which the broker added out of the IP filter", func() {
+			os.Setenv("IP_WHITELIST", "198.51.100.7")
+			defer os.Unsetenv("IP_WHITELIST")
+			config.Catalog.Services[0].Plans[1].IPFilterPolicy.MandatoryRanges = []string{"192.0.2.0/24"}
+			service := &aiven.Service{State: aiven.Running}
+			service.UserConfig.IPFilter = aiven.NewIPFilter("198.51.100.7/32", "192.0.2.0/24", "1.2.3.4")
+			fakeAivenClient.GetServiceReturns(service, nil)
+			fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{PlanID: "uuid-3"}, nil)
+
+			spec, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Parameters.(provider.InstanceParameters).UserIpFilter).To(Equal("1.2.3.4"))
+		})
+
+		It("includes the source of a restored instance", func() {
+			fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{
+				PlanID:             "uuid-2",
+				RestoredFromBackup: "true",
+				OriginServiceID:    "source-instance",
+			}, nil)
+
+			spec, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Parameters).To(Equal(provider.InstanceParameters{
+				RestoreFromLatestBackupOf: "source-instance",
+			}))
+		})
+
+		It("includes the source and time of an instance restored to a point in time", func() {
+			fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{
+				PlanID:                  "uuid-2",
+				RestoredFromBackup:      "true",
+				OriginServiceID:         "source-instance",
+				RestoredFromTime:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				RestoredFromPointInTime: "true",
+			}, nil)
+
+			spec, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Parameters).To(Equal(provider.InstanceParameters{
+				RestoreFromPointInTimeOf:     "source-instance",
+				RestoreFromPointInTimeBefore: "2024-01-02 03:04:05",
+			}))
+		})
+
+		It("includes termination protection", func() {
+			fakeAivenClient.GetServiceReturns(&aiven.Service{State: aiven.Running, TerminationProtection: true}, nil)
+			fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{PlanID: "uuid-2"}, nil)
+
+			spec, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Parameters.(provider.InstanceParameters).TerminationProtection).To(BeTrue())
+		})
+
+		It("includes the maintenance window", func() {
+			fakeAivenClient.GetServiceReturns(&aiven.Service{
+				State:       aiven.Running,
+				Maintenance: &aiven.Maintenance{DOW: "sunday", Time: "03:00:00"},
+			}, nil)
+			fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{PlanID: "uuid-2"}, nil)
+
+			spec, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Parameters.(provider.InstanceParameters).MaintenanceWindow).To(Equal(&provider.MaintenanceWindow{
+				DOW:  "sunday",
+				Time: "03:00:00",
+			}))
+		})
+
+		It("returns ErrInstanceNotFound if the service does not exist", func() {
+			fakeAivenClient.GetServiceReturns(nil, aiven.ErrInstanceDoesNotExist)
+
+			_, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).To(Equal(provider.ErrInstanceNotFound))
+		})
+
+		It("returns ErrInstanceNotFound if Aiven responds with a 404", func() {
+			fakeAivenClient.GetServiceReturns(nil, &aiven.APIError{StatusCode: http.StatusNotFound})
+
+			_, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).To(Equal(provider.ErrInstanceNotFound))
+		})
+
+		It("errors if the plan in the tags is not in the catalog", func() {
+			fakeAivenClient.GetServiceTagsReturns(&aiven.ServiceTags{PlanID: "unknown"}, nil)
+
+			_, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).To(MatchError("could not find service with plan id unknown"))
+		})
+
+		It("errors if the client fails to get the service tags", func() {
+			fakeAivenClient.GetServiceTagsReturns(nil, errors.New("some-error"))
+
+			_, err := aivenProvider.GetInstance(context.Background(), getInstanceData)
+			Expect(err).To(MatchError("some-error"))
+		})
+	})
+
+	Describe("ListBackups", func() {
+		var listBackupsData provider.ListBackupsData
+
+		BeforeEach(func() {
+			listBackupsData = provider.ListBackupsData{
+				InstanceID: "09E1993E-62E2-4040-ADF2-4D3EC741EFE6",
+			}
+		})
+
+		It("returns the backups of the instance, newest first", func() {
+			older := time.Now().Add(-48 * time.Hour)
+			newer := time.Now().Add(-24 * time.Hour)
+			fakeAivenClient.ListServiceBackupsReturns([]aiven.ServiceBackup{
+				{Name: "older", Time: older, Size: 100},
+				{Name: "newer", Time: newer, Size: 200},
+			}, nil)
+
+			backups, err := aivenProvider.ListBackups(context.Background(), listBackupsData)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backups).To(Equal([]provider.Backup{
+				{Name: "newer", Time: newer, Size: 200},
+				{Name: "older", Time: older, Size: 100},
+			}))
+
+			_, listServiceBackupsArgs := fakeAivenClient.ListServiceBackupsArgsForCall(0)
+			Expect(listServiceBackupsArgs.ServiceName).To(Equal("env-09e1993e-62e2-4040-adf2-4d3ec741efe6"))
+		})
+
+		It("returns ErrInstanceNotFound if the service does not exist", func() {
+			fakeAivenClient.ListServiceBackupsReturns(nil, aiven.ErrInstanceDoesNotExist)
+
+			_, err := aivenProvider.ListBackups(context.Background(), listBackupsData)
+			Expect(err).To(Equal(provider.ErrInstanceNotFound))
+		})
+	})
+
 	Describe("checkPermissionsFromTags", func() {
 		var provisionData provider.ProvisionData
 		BeforeEach(func() {
@@ -788,42 +2840,6 @@ var _ = Describe("Provider", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
-	Describe("The ParseIPWhitelist function", func() {
-		It("parses an empty string as an empty list", func() {
-			Expect(provider.ParseIPWhitelist("")).
-				To(BeEmpty())
-		})
-
-		It("parses a single IP", func() {
-			Expect(provider.ParseIPWhitelist("127.0.0.1")).
-				To(Equal([]string{"127.0.0.1"}))
-		})
-
-		It("parses multiple IPs", func() {
-			Expect(provider.ParseIPWhitelist("127.0.0.1,99.99.99.99")).
-				To(Equal([]string{"127.0.0.1", "99.99.99.99"}))
-		})
-
-		It("returns an error for IPs containing the wrong number of octets", func() {
-			var err error
-			By("not permitting too many octets")
-			_, err = provider.ParseIPWhitelist("127.0.0.0.1")
-			Expect(err).To(HaveOccurred())
-			By("not permitting too few octets")
-			_, err = provider.ParseIPWhitelist("127.0.1")
-			Expect(err).To(HaveOccurred())
-			By("not permitting too few octets even when valid IPs are present")
-			_, err = provider.ParseIPWhitelist("8.8.8.8,127.0.1")
-			Expect(err).To(HaveOccurred())
-		})
-
-		It("returns an error for garbage IPs", func() {
-			_, err := provider.ParseIPWhitelist("ojnratuh53ggijntboijngk3,0ij90490ti9jo43p;';;1;'")
-			Expect(err).To(HaveOccurred())
-		})
-
-	})
-
 	DescribeTable("The buildServiceName function",
 		func(instanceId, expected string) {
 			Expect(aivenProvider.BuildServiceName(instanceId)).To(Equal(expected))
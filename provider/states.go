@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alphagov/paas-aiven-broker/provider/aiven"
+	"github.com/pivotal-cf/brokerapi/domain"
+)
+
+func providerStatesMapping(service *aiven.Service) (domain.LastOperationState, string) {
+	switch service.State {
+	case aiven.Running:
+		return domain.Succeeded, "Last operation succeeded"
+	case aiven.Rebuilding:
+		if node, ok := timedOutNode(service.NodeStates); ok {
+			return domain.Failed, fmt.Sprintf("Last operation failed: node %s timed out while rebuilding", node.Name)
+		}
+		return domain.InProgress, withNodeStates("Rebuilding", service.NodeStates)
+	case aiven.Rebalancing:
+		return domain.InProgress, withNodeStates("Rebalancing", service.NodeStates)
+	case aiven.PowerOff:
+		return domain.Failed, "Last operation failed: service is powered off"
+	case aiven.Missing:
+		return domain.Failed, "Last operation failed: service does not exist"
+	default:
+		return domain.InProgress, withNodeStates(fmt.Sprintf("Unknown state: %s", service.State), service.NodeStates)
+	}
+}
+
+// Aiven marks nodes which fail to come up within its own deadline as timing
+// out, after which the rebuild will not complete without intervention
+func timedOutNode(nodes []aiven.NodeState) (aiven.NodeState, bool) {
+	for _, node := range nodes {
+		if node.State == aiven.NodeTimingOut {
+			return node, true
+		}
+	}
+	return aiven.NodeState{}, false
+}
+
+// withNodeStates adds the nodes which are not yet running to the
+// description, so that users can tell which node is holding up the operation
+func withNodeStates(description string, nodes []aiven.NodeState) string {
+	if len(nodes) == 0 {
+		return description
+	}
+
+	running := 0
+	lagging := []string{}
+	for _, node := range nodes {
+		if node.State == aiven.NodeRunning {
+			running++
+			continue
+		}
+		lagging = append(lagging, describeNode(node))
+	}
+	if len(lagging) == 0 {
+		return description
+	}
+	return fmt.Sprintf(
+		"%s: %d of %d nodes running; %s",
+		description, running, len(nodes), strings.Join(lagging, "; "),
+	)
+}
+
+func describeNode(node aiven.NodeState) string {
+	description := fmt.Sprintf("%s is %s", node.Name, strings.ReplaceAll(string(node.State), "_", " "))
+	for _, update := range node.ProgressUpdates {
+		if update.Completed {
+			continue
+		}
+		phase := strings.ReplaceAll(update.Phase, "_", " ")
+		if update.Max > update.Min {
+			percentage := 100 * (update.Current - update.Min) / (update.Max - update.Min)
+			return fmt.Sprintf("%s (%s %d%%)", description, phase, percentage)
+		}
+		return fmt.Sprintf("%s (%s)", description, phase)
+	}
+	return description
+}